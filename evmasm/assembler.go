@@ -0,0 +1,261 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package evmasm assembles EVM bytecode from the evmdis listings and from a conventional assembly syntax.
+package evmasm
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+type Assembler struct {
+	fork evmops.Fork
+}
+
+// Option configures an Assembler.
+type Option func(*Assembler)
+
+// WithFork sets the fork the code is assembled for, the latest fork by default.
+// PUSH0 is used for PUSH of zero without an explicit width only since Shanghai.
+func WithFork(f evmops.Fork) Option {
+	return func(a *Assembler) {
+		a.fork = f
+	}
+}
+
+func NewAssembler(opts ...Option) *Assembler {
+	a := &Assembler{
+		fork: evmops.LatestFork,
+	}
+	for _, opt := range opts {
+		opt(a)
+	}
+
+	return a
+}
+
+// Assemble assembles the source in the conventional syntax:
+//
+//	; comments start with ';', '//' or '#'
+//	    PUSH 0x80           ; PUSH with the minimal width
+//	    PUSH1 64            ; decimal immediates
+//	    MSTORE
+//	    PUSH @end           ; the offset of the label
+//	    JUMP
+//	    .data 0xdeadbeef    ; raw bytes
+//	end:
+//	    JUMPDEST
+//	    STOP
+//
+// Mnemonics are case-insensitive, labels are defined with a trailing colon and refer
+// to the offset of the next instruction. A label pushed right before JUMP or JUMPI must refer
+// to JUMPDEST. PUSH without an explicit width gets the minimal one, including PUSH of a label,
+// whose width is chosen so that all the offsets are stable.
+func (a *Assembler) Assemble(src string) ([]byte, error) {
+	stmts, err := parseSource(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.assemble(stmts)
+}
+
+// AssembleListing assembles the text produced by evmdis.Results.String or evmops.Line.String.
+// Comments, including program counters, are ignored.
+func (a *Assembler) AssembleListing(src string) ([]byte, error) {
+	stmts, err := parseListing(src)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.assemble(stmts)
+}
+
+// AssembleLines assembles disassembled lines back into bytecode.
+// The immediates are emitted exactly as they are in the lines.
+func (a *Assembler) AssembleLines(lines []evmops.Line) ([]byte, error) {
+	var code []byte
+	for _, line := range lines {
+		n := 0
+		for _, arg := range line.Args {
+			n += len(arg)
+		}
+
+		if n > pushWidth(line.Inst.Code) {
+			return nil, fmt.Errorf("evmasm: pc=%d: %s immediate is too long", line.ProgramCounter, line.Inst.Mnemonic)
+		}
+
+		code = append(code, byte(line.Inst.Code))
+		for _, arg := range line.Args {
+			code = append(code, arg...)
+		}
+	}
+
+	return code, nil
+}
+
+// Assemble assembles the source in the conventional syntax for the latest fork, see Assembler.Assemble.
+func Assemble(src string) ([]byte, error) {
+	return NewAssembler().Assemble(src)
+}
+
+func (a *Assembler) assemble(stmts []statement) ([]byte, error) {
+	labels := map[string]int{}
+	for i, st := range stmts {
+		if st.label == "" {
+			continue
+		}
+
+		if _, ok := labels[st.label]; ok {
+			return nil, &SyntaxError{st.line, fmt.Sprintf("label %q redefined", st.label)}
+		}
+		labels[st.label] = i
+	}
+
+	widths := make([]int, len(stmts))
+	for i, st := range stmts {
+		switch {
+		case st.ref != "":
+			if _, ok := labels[st.ref]; !ok {
+				return nil, &SyntaxError{st.line, fmt.Sprintf("undefined label %q", st.ref)}
+			}
+			if isJump(nextInstruction(stmts, i+1)) && !isJumpDest(nextInstruction(stmts, labels[st.ref])) {
+				return nil, &SyntaxError{st.line, fmt.Sprintf("jump target %q is not a JUMPDEST", st.ref)}
+			}
+			widths[i] = a.minWidth(0)
+			if !st.auto {
+				widths[i] = pushWidth(st.op)
+			}
+		case st.auto:
+			widths[i] = a.minWidth(len(st.arg))
+		case st.exact:
+			widths[i] = len(st.arg)
+		default:
+			widths[i] = pushWidth(st.op)
+		}
+	}
+
+	// widths of the label PUSHes only grow, so the iteration converges
+	var offsets []int
+	for changed := true; changed; {
+		changed = false
+		offsets = layout(stmts, widths)
+
+		for i, st := range stmts {
+			if st.ref == "" {
+				continue
+			}
+
+			need := a.minWidth(len(big.NewInt(int64(offsets[labels[st.ref]])).Bytes()))
+			if need <= widths[i] {
+				continue
+			}
+
+			if !st.auto {
+				return nil, &SyntaxError{st.line, fmt.Sprintf("offset of %q does not fit in %d bytes", st.ref, widths[i])}
+			}
+
+			widths[i] = need
+			changed = true
+		}
+	}
+
+	var code []byte
+	for i, st := range stmts {
+		switch {
+		case st.label != "":
+			continue
+		case st.data != nil:
+			code = append(code, st.data...)
+			continue
+		}
+
+		arg := st.arg
+		if st.ref != "" {
+			arg = big.NewInt(int64(offsets[labels[st.ref]])).Bytes()
+		}
+
+		op := st.op
+		if st.auto {
+			op = opPUSH0
+			if widths[i] > 0 {
+				op = opPUSH1 + evmops.Opcode(widths[i]-1)
+			}
+		}
+
+		code = append(code, byte(op))
+		if st.exact {
+			code = append(code, arg...)
+			continue
+		}
+
+		// left-pad the immediate to the PUSH width
+		for j := len(arg); j < widths[i]; j++ {
+			code = append(code, 0)
+		}
+		code = append(code, arg...)
+	}
+
+	return code, nil
+}
+
+// nextInstruction returns the first statement from the i-th one that is not a label, or nil if there is none.
+func nextInstruction(stmts []statement, i int) *statement {
+	for ; i < len(stmts); i++ {
+		if stmts[i].label == "" {
+			return &stmts[i]
+		}
+	}
+
+	return nil
+}
+
+func isJump(st *statement) bool {
+	return st != nil && st.data == nil && (st.op == opJUMP || st.op == opJUMPI)
+}
+
+func isJumpDest(st *statement) bool {
+	return st != nil && st.data == nil && st.op == opJUMPDEST
+}
+
+// minWidth returns the minimal PUSH width for an immediate of n significant bytes.
+func (a *Assembler) minWidth(n int) int {
+	if n == 0 && a.fork < evmops.Shanghai {
+		return 1
+	}
+
+	return n
+}
+
+// layout returns the offsets of the statements for the given PUSH widths.
+func layout(stmts []statement, widths []int) []int {
+	offsets := make([]int, len(stmts))
+	offset := 0
+	for i, st := range stmts {
+		offsets[i] = offset
+
+		switch {
+		case st.label != "":
+		case st.data != nil:
+			offset += len(st.data)
+		default:
+			offset += 1 + widths[i]
+		}
+	}
+
+	return offsets
+}
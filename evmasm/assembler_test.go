@@ -0,0 +1,210 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmasm
+
+import (
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+func TestAssembler_Assemble(t *testing.T) {
+	tests := []struct {
+		name    string
+		a       *Assembler
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "free memory pointer",
+			src: `
+				push 0x80 ; comment
+				PUSH1 64  // decimal
+				MSTORE    # another comment
+			`,
+			want: "6080604052",
+		},
+		{
+			name: "labels",
+			src: `
+				PUSH @end
+				JUMP
+			loop: JUMPDEST
+				PUSH @loop
+				JUMP
+			end:
+				JUMPDEST
+				STOP
+			`,
+			want: "6007565b600356" + "5b00",
+		},
+		{
+			name: "explicit width of label",
+			src: `
+				PUSH2 @end
+				JUMP
+			end: JUMPDEST
+			`,
+			want: "610004565b",
+		},
+		{
+			name: "push zero",
+			src:  "PUSH 0\nPUSH 0x0000",
+			want: "5f5f",
+		},
+		{
+			name: "push zero before shanghai",
+			a:    NewAssembler(WithFork(evmops.London)),
+			src:  "PUSH 0",
+			want: "6000",
+		},
+		{
+			name: "wide immediates",
+			src:  "PUSH 65536\nPUSH32 0x01\nPUSH4 0xa9059cbb",
+			want: "62010000" + "7f" + strings.Repeat("00", 31) + "01" + "63a9059cbb",
+		},
+		{
+			name: "label widening",
+			// 300 bytes of data push the label beyond PUSH1
+			src:  "PUSH @end\n.data 0x" + strings.Repeat("00", 300) + "\nend: JUMPDEST",
+			want: "61012f" + strings.Repeat("00", 300) + "5b",
+		},
+		{
+			name: "aliases",
+			src:  "SHA3\nKECCAK256\nDIFFICULTY\nPREVRANDAO\nSUICIDE",
+			want: "20204444ff",
+		},
+		{
+			name:    "unknown instruction",
+			src:     "PUSH 1\nFOO",
+			wantErr: true,
+		},
+		{
+			name:    "undefined label",
+			src:     "PUSH @nowhere",
+			wantErr: true,
+		},
+		{
+			name:    "jump to non-jumpdest",
+			src:     "PUSH @end\nJUMP\nend: STOP",
+			wantErr: true,
+		},
+		{
+			name:    "conditional jump to data",
+			src:     "PUSH 1\nPUSH @end\nJUMPI\nend:\n.data 0x5b",
+			wantErr: true,
+		},
+		{
+			name:    "jump to end of code",
+			src:     "PUSH @end\nJUMP\nend:",
+			wantErr: true,
+		},
+		{
+			name: "label pushed as data offset",
+			src:  "PUSH @end\nPOP\nend: STOP",
+			want: "60035000",
+		},
+		{
+			name:    "redefined label",
+			src:     "a: STOP\na: STOP",
+			wantErr: true,
+		},
+		{
+			name:    "immediate overflow",
+			src:     "PUSH1 256",
+			wantErr: true,
+		},
+		{
+			name:    "label overflow",
+			src:     "PUSH1 @end\n.data 0x" + strings.Repeat("00", 300) + "\nend: JUMPDEST",
+			wantErr: true,
+		},
+		{
+			name:    "operand of non-push",
+			src:     "ADD 1",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := tt.a
+			if a == nil {
+				a = NewAssembler()
+			}
+
+			got, err := a.Assemble(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Assembler.Assemble() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err != nil {
+				var syntaxErr *SyntaxError
+				if !errors.As(err, &syntaxErr) {
+					t.Errorf("Assembler.Assemble() error = %T, want *SyntaxError", err)
+				}
+				return
+			}
+
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("Assembler.Assemble() = %x, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAssembler_AssembleListing(t *testing.T) {
+	tests := []struct {
+		name    string
+		src     string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "listing",
+			src: "PUSH1 80 ; pc=0\n" +
+				"PUSH1 40 ; pc=2\n" +
+				"MSTORE ; pc=4\n" +
+				"PUSH4 a9059cbb ; transfer(address,uint256) pc=5\n" +
+				"__UNDEFINED_INSTRUCTION(0c) ; pc=10\n",
+			want: "60806040526" + "3a9059cbb" + "0c",
+		},
+		{
+			name: "truncated push",
+			src:  "STOP ; pc=0\nPUSH2 aa ; pc=1\n",
+			want: "0061aa",
+		},
+		{
+			name:    "too long immediate",
+			src:     "PUSH1 aabb ; pc=0",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAssembler().AssembleListing(tt.src)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Assembler.AssembleListing() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if err == nil && hex.EncodeToString(got) != tt.want {
+				t.Errorf("Assembler.AssembleListing() = %x, want %s", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,325 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmasm
+
+import (
+	"encoding/hex"
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+const (
+	opJUMP     evmops.Opcode = 0x56
+	opJUMPI    evmops.Opcode = 0x57
+	opJUMPDEST evmops.Opcode = 0x5b
+
+	opPUSH0  evmops.Opcode = 0x5f
+	opPUSH1  evmops.Opcode = 0x60
+	opPUSH32 evmops.Opcode = 0x7f
+)
+
+// SyntaxError is an error in the assembly source.
+type SyntaxError struct {
+	// Line is the 1-based line number in the source
+	Line int
+	Msg  string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("evmasm: line %d: %s", e.Line, e.Msg)
+}
+
+// statement is a single parsed instruction, label or data directive.
+type statement struct {
+	line int
+
+	// label is the defined label, for label statements
+	label string
+
+	// data is emitted as is, for .data directives
+	data []byte
+
+	op evmops.Opcode
+
+	// auto is true for PUSH without an explicit width
+	auto bool
+
+	// arg is the immediate of PUSH given as bytes, emitted with the PUSH width,
+	// or as is if exact is true
+	arg   []byte
+	exact bool
+
+	// ref is the label the PUSH refers to
+	ref string
+}
+
+// mnemonics maps upper-case mnemonics of all the forks and their well-known aliases to opcodes.
+var mnemonics = map[string]evmops.Opcode{
+	"SUICIDE":    0xff,
+	"DIFFICULTY": 0x44,
+	"KECCAK256":  0x20,
+}
+
+func init() {
+	for f := evmops.Frontier; f <= evmops.LatestFork; f++ {
+		for _, inst := range evmops.ForkInstructionSet(f) {
+			if !strings.HasPrefix(inst.Mnemonic, "__UNDEFINED_INSTRUCTION") {
+				mnemonics[inst.Mnemonic] = inst.Code
+			}
+		}
+	}
+}
+
+// parseListing parses the output of evmops.Line.String, one instruction per line:
+//
+//	PUSH1 80 ; pc=0
+//
+// PUSH immediates are hex without prefix and are emitted exactly as written,
+// so truncated PUSH data at the end of the code round-trips.
+func parseListing(src string) ([]statement, error) {
+	var stmts []statement
+
+	for i, text := range strings.Split(src, "\n") {
+		lineNo := i + 1
+		if j := strings.IndexByte(text, ';'); j >= 0 {
+			text = text[:j]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		op, err := parseMnemonic(fields[0])
+		if err != nil {
+			return nil, &SyntaxError{lineNo, err.Error()}
+		}
+
+		st := statement{line: lineNo, op: op, exact: true}
+		switch {
+		case len(fields) > 2:
+			return nil, &SyntaxError{lineNo, "too many operands"}
+		case len(fields) == 2:
+			if !isPush(op) {
+				return nil, &SyntaxError{lineNo, fields[0] + " takes no operands"}
+			}
+
+			st.arg, err = hex.DecodeString(fields[1])
+			if err != nil {
+				return nil, &SyntaxError{lineNo, "invalid hex immediate " + strconv.Quote(fields[1])}
+			}
+
+			if len(st.arg) > pushWidth(op) {
+				return nil, &SyntaxError{lineNo, fmt.Sprintf("immediate is longer than %d bytes", pushWidth(op))}
+			}
+		}
+
+		stmts = append(stmts, st)
+	}
+
+	return stmts, nil
+}
+
+// parseSource parses the conventional assembly syntax, see Assembler.Assemble.
+func parseSource(src string) ([]statement, error) {
+	var stmts []statement
+
+	for i, text := range strings.Split(src, "\n") {
+		lineNo := i + 1
+		text = stripComment(text)
+
+		for {
+			text = strings.TrimSpace(text)
+			j := strings.IndexByte(text, ':')
+			if j <= 0 || !isIdent(text[:j]) {
+				break
+			}
+
+			stmts = append(stmts, statement{line: lineNo, label: text[:j]})
+			text = text[j+1:]
+		}
+
+		fields := strings.Fields(text)
+		if len(fields) == 0 {
+			continue
+		}
+
+		if strings.EqualFold(fields[0], ".data") {
+			if len(fields) != 2 {
+				return nil, &SyntaxError{lineNo, ".data takes a single hex operand"}
+			}
+
+			data, err := hex.DecodeString(strings.TrimPrefix(strings.TrimPrefix(fields[1], "0x"), "0X"))
+			if err != nil {
+				return nil, &SyntaxError{lineNo, "invalid hex data " + strconv.Quote(fields[1])}
+			}
+
+			stmts = append(stmts, statement{line: lineNo, data: data})
+			continue
+		}
+
+		st := statement{line: lineNo}
+		if strings.EqualFold(fields[0], "PUSH") {
+			st.op = opPUSH1
+			st.auto = true
+		} else {
+			op, err := parseMnemonic(fields[0])
+			if err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+			st.op = op
+		}
+
+		switch {
+		case len(fields) > 2:
+			return nil, &SyntaxError{lineNo, "too many operands"}
+
+		case len(fields) == 2:
+			if !st.auto && !isPush(st.op) {
+				return nil, &SyntaxError{lineNo, fields[0] + " takes no operands"}
+			}
+
+			if err := st.parseOperand(fields[1]); err != nil {
+				return nil, &SyntaxError{lineNo, err.Error()}
+			}
+
+			if !st.auto && st.ref == "" && len(st.arg) > pushWidth(st.op) {
+				return nil, &SyntaxError{lineNo, fmt.Sprintf("immediate does not fit in %d bytes", pushWidth(st.op))}
+			}
+
+		case st.auto || isPush(st.op):
+			return nil, &SyntaxError{lineNo, fields[0] + " requires an operand"}
+		}
+
+		stmts = append(stmts, st)
+	}
+
+	return stmts, nil
+}
+
+// parseOperand parses a PUSH operand: @label, 0x-prefixed hex or decimal.
+func (st *statement) parseOperand(s string) error {
+	if strings.HasPrefix(s, "@") {
+		if !isIdent(s[1:]) {
+			return fmt.Errorf("invalid label reference %q", s)
+		}
+		st.ref = s[1:]
+		return nil
+	}
+
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		digits := s[2:]
+		if len(digits)%2 == 1 {
+			digits = "0" + digits
+		}
+
+		arg, err := hex.DecodeString(digits)
+		if err != nil || len(digits) == 0 {
+			return fmt.Errorf("invalid hex immediate %q", s)
+		}
+
+		if len(arg) > 32 {
+			return fmt.Errorf("immediate %q is longer than 32 bytes", s)
+		}
+
+		if st.auto {
+			arg = trimLeadingZeros(arg)
+		}
+		st.arg = arg
+
+		return nil
+	}
+
+	v, ok := new(big.Int).SetString(s, 10)
+	if !ok || v.Sign() < 0 {
+		return fmt.Errorf("invalid immediate %q", s)
+	}
+
+	if v.BitLen() > 256 {
+		return fmt.Errorf("immediate %q is larger than 256 bits", s)
+	}
+	st.arg = v.Bytes()
+
+	return nil
+}
+
+func parseMnemonic(s string) (evmops.Opcode, error) {
+	upper := strings.ToUpper(s)
+	if op, ok := mnemonics[upper]; ok {
+		return op, nil
+	}
+
+	// undefined instructions are listed as __UNDEFINED_INSTRUCTION(xx)
+	if strings.HasPrefix(upper, "__UNDEFINED_INSTRUCTION(") && strings.HasSuffix(upper, ")") {
+		b, err := hex.DecodeString(upper[len("__UNDEFINED_INSTRUCTION(") : len(upper)-1])
+		if err == nil && len(b) == 1 {
+			return evmops.Opcode(b[0]), nil
+		}
+	}
+
+	return 0, fmt.Errorf("unknown instruction %q", s)
+}
+
+func stripComment(s string) string {
+	for _, marker := range []string{";", "//", "#"} {
+		if i := strings.Index(s, marker); i >= 0 {
+			s = s[:i]
+		}
+	}
+
+	return s
+}
+
+func isIdent(s string) bool {
+	if s == "" {
+		return false
+	}
+
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '.' || r == '$':
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+func isPush(op evmops.Opcode) bool {
+	return op >= opPUSH1 && op <= opPUSH32
+}
+
+func pushWidth(op evmops.Opcode) int {
+	if !isPush(op) {
+		return 0
+	}
+
+	return int(op-opPUSH1) + 1
+}
+
+func trimLeadingZeros(b []byte) []byte {
+	for len(b) > 0 && b[0] == 0 {
+		b = b[1:]
+	}
+
+	return b
+}
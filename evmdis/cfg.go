@@ -0,0 +1,354 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Opcodes the control-flow analysis cares about.
+const (
+	opSTOP         evmops.Opcode = 0x00
+	opJUMP         evmops.Opcode = 0x56
+	opJUMPI        evmops.Opcode = 0x57
+	opJUMPDEST     evmops.Opcode = 0x5b
+	opPUSH1        evmops.Opcode = 0x60
+	opPUSH32       evmops.Opcode = 0x7f
+	opRETURN       evmops.Opcode = 0xf3
+	opREVERT       evmops.Opcode = 0xfd
+	opINVALID      evmops.Opcode = 0xfe
+	opSELFDESTRUCT evmops.Opcode = 0xff
+)
+
+// EdgeKind is the kind of a control-flow transition between basic blocks.
+type EdgeKind uint8
+
+const (
+	// EdgeFallthrough is a sequential transition into the next block,
+	// including the not taken branch of JUMPI.
+	EdgeFallthrough EdgeKind = iota
+
+	// EdgeJump is a JUMP to a statically known destination.
+	EdgeJump
+
+	// EdgeConditionalJump is the taken branch of JUMPI to a statically known destination.
+	EdgeConditionalJump
+)
+
+func (k EdgeKind) String() string {
+	switch k {
+	case EdgeFallthrough:
+		return "fallthrough"
+	case EdgeJump:
+		return "jump"
+	case EdgeConditionalJump:
+		return "conditional jump"
+	default:
+		return "invalid"
+	}
+}
+
+// Edge is a control-flow transition between basic blocks identified by their IDs.
+type Edge struct {
+	From int
+	To   int
+	Kind EdgeKind
+}
+
+// Block is a basic block: a straight sequence of instructions with a single entry and a single exit.
+type Block struct {
+	// ID is the index of the block in CFG.Blocks
+	ID int
+
+	// Start is the program counter of the first instruction of the block
+	Start uint64
+
+	// End is the program counter of the last instruction of the block
+	End uint64
+
+	Lines []evmops.Line
+
+	// UnresolvedJump is true if the block ends with JUMP or JUMPI with a destination
+	// that is not statically known. AnalyzeStack clears it for the jumps it resolves.
+	UnresolvedJump bool
+
+	// Reachable is false if there is no path from the contract entry to the block.
+	// Any JUMPDEST is considered reachable as soon as a reachable block has an unresolved jump.
+	Reachable bool
+}
+
+// Terminator returns the last instruction of the block.
+func (b *Block) Terminator() evmops.Line {
+	return b.Lines[len(b.Lines)-1]
+}
+
+// IsJumpDest returns true if the block starts with JUMPDEST, i.e. it can be a jump destination.
+func (b *Block) IsJumpDest() bool {
+	return b.Lines[0].Inst.Code == opJUMPDEST
+}
+
+// StaticGas returns the static gas cost of executing the block and its dynamic gas components,
+// in the fork the code was disassembled for. See evmops.StaticGas.
+func (b *Block) StaticGas() (uint64, evmops.DynamicGas) {
+	return evmops.StaticGas(b.Lines)
+}
+
+// CFG is the control-flow graph of the disassembled code.
+type CFG struct {
+	Blocks []*Block
+	Edges  []Edge
+
+	byStart map[uint64]int
+	succs   map[int][]Edge
+	preds   map[int][]Edge
+}
+
+// BuildCFG splits disassembly results into basic blocks and connects them with statically known edges.
+//
+// Blocks are split before JUMPDEST and after JUMP, JUMPI, STOP, RETURN, REVERT, INVALID,
+// SELFDESTRUCT and undefined instructions. A jump destination is statically known if the jump
+// immediately follows a PUSH instruction and points to a JUMPDEST.
+func BuildCFG(r *Results) *CFG {
+	g := &CFG{
+		byStart: map[uint64]int{},
+	}
+
+	var cur *Block
+	for _, line := range r.Lines {
+		if cur != nil && line.Inst.Code == opJUMPDEST {
+			cur = nil
+		}
+
+		if cur == nil {
+			cur = &Block{
+				ID:    len(g.Blocks),
+				Start: line.ProgramCounter,
+			}
+			g.Blocks = append(g.Blocks, cur)
+			g.byStart[cur.Start] = cur.ID
+		}
+
+		cur.Lines = append(cur.Lines, line)
+		cur.End = line.ProgramCounter
+
+		if isBlockTerminator(line.Inst) {
+			cur = nil
+		}
+	}
+
+	for i, b := range g.Blocks {
+		term := b.Terminator()
+		if !halts(term.Inst) && term.Inst.Code != opJUMP && i+1 < len(g.Blocks) {
+			g.Edges = append(g.Edges, Edge{From: b.ID, To: i + 1, Kind: EdgeFallthrough})
+		}
+
+		if term.Inst.Code != opJUMP && term.Inst.Code != opJUMPI {
+			continue
+		}
+
+		kind := EdgeJump
+		if term.Inst.Code == opJUMPI {
+			kind = EdgeConditionalJump
+		}
+
+		dest, ok := staticJumpDest(b)
+		if !ok {
+			b.UnresolvedJump = true
+			continue
+		}
+
+		// jumps to anything but JUMPDEST fail at runtime, so there is no edge
+		if to, ok := g.jumpDestBlock(dest); ok {
+			g.Edges = append(g.Edges, Edge{From: b.ID, To: to, Kind: kind})
+		}
+	}
+
+	g.index()
+	g.computeReachability()
+
+	return g
+}
+
+// BlockAt returns the block starting at the given program counter.
+func (g *CFG) BlockAt(pc uint64) (*Block, bool) {
+	id, ok := g.byStart[pc]
+	if !ok {
+		return nil, false
+	}
+
+	return g.Blocks[id], true
+}
+
+// BlockContaining returns the block containing the instruction at the given program counter.
+func (g *CFG) BlockContaining(pc uint64) (*Block, bool) {
+	i := sort.Search(len(g.Blocks), func(i int) bool {
+		return g.Blocks[i].End >= pc
+	})
+	if i == len(g.Blocks) || g.Blocks[i].Start > pc {
+		return nil, false
+	}
+
+	return g.Blocks[i], true
+}
+
+// Successors returns outgoing edges of the block.
+func (g *CFG) Successors(id int) []Edge {
+	return g.succs[id]
+}
+
+// Predecessors returns incoming edges of the block.
+func (g *CFG) Predecessors(id int) []Edge {
+	return g.preds[id]
+}
+
+// Unreachable returns blocks that can't be reached from the contract entry.
+func (g *CFG) Unreachable() []*Block {
+	var blocks []*Block
+	for _, b := range g.Blocks {
+		if !b.Reachable {
+			blocks = append(blocks, b)
+		}
+	}
+
+	return blocks
+}
+
+func (g *CFG) jumpDestBlock(dest uint64) (int, bool) {
+	id, ok := g.byStart[dest]
+	if !ok || !g.Blocks[id].IsJumpDest() {
+		return 0, false
+	}
+
+	return id, true
+}
+
+// addEdge adds an edge unless it is already present, and returns true if it was added.
+// The edges are left in the order they were added until index is called.
+func (g *CFG) addEdge(e Edge) bool {
+	for _, known := range g.succs[e.From] {
+		if known == e {
+			return false
+		}
+	}
+
+	g.Edges = append(g.Edges, e)
+	g.succs[e.From] = append(g.succs[e.From], e)
+	g.preds[e.To] = append(g.preds[e.To], e)
+
+	return true
+}
+
+func (g *CFG) index() {
+	sort.SliceStable(g.Edges, func(i, j int) bool {
+		a, b := g.Edges[i], g.Edges[j]
+		if a.From != b.From {
+			return a.From < b.From
+		}
+		if a.Kind != b.Kind {
+			return a.Kind < b.Kind
+		}
+		return a.To < b.To
+	})
+
+	g.succs = map[int][]Edge{}
+	g.preds = map[int][]Edge{}
+	for _, e := range g.Edges {
+		g.succs[e.From] = append(g.succs[e.From], e)
+		g.preds[e.To] = append(g.preds[e.To], e)
+	}
+}
+
+func (g *CFG) computeReachability() {
+	for _, b := range g.Blocks {
+		b.Reachable = false
+	}
+
+	if len(g.Blocks) == 0 {
+		return
+	}
+
+	var queue []int
+	visit := func(id int) {
+		if !g.Blocks[id].Reachable {
+			g.Blocks[id].Reachable = true
+			queue = append(queue, id)
+		}
+	}
+
+	visit(0)
+	dynamicJumps := false
+	for len(queue) > 0 {
+		id := queue[0]
+		queue = queue[1:]
+
+		if g.Blocks[id].UnresolvedJump && !dynamicJumps {
+			dynamicJumps = true
+			for _, b := range g.Blocks {
+				if b.IsJumpDest() {
+					visit(b.ID)
+				}
+			}
+		}
+
+		for _, e := range g.succs[id] {
+			visit(e.To)
+		}
+	}
+}
+
+// staticJumpDest returns the jump destination of the block ending with JUMP or JUMPI
+// if it is pushed right before the jump.
+func staticJumpDest(b *Block) (uint64, bool) {
+	if len(b.Lines) < 2 {
+		return 0, false
+	}
+
+	return pushValue(b.Lines[len(b.Lines)-2])
+}
+
+// pushValue returns the value pushed by PUSH1-PUSH8 instructions.
+func pushValue(line evmops.Line) (uint64, bool) {
+	if line.Inst.Code < opPUSH1 || line.Inst.Code >= opPUSH1+8 || len(line.Args) != 1 {
+		return 0, false
+	}
+
+	var v uint64
+	for _, b := range []byte(line.Args[0]) {
+		v = v<<8 | uint64(b)
+	}
+
+	return v, true
+}
+
+func isBlockTerminator(inst evmops.Instruction) bool {
+	return halts(inst) || inst.Code == opJUMP || inst.Code == opJUMPI
+}
+
+// halts returns true if the instruction stops the execution of the current context.
+func halts(inst evmops.Instruction) bool {
+	switch inst.Code {
+	case opSTOP, opRETURN, opREVERT, opINVALID, opSELFDESTRUCT:
+		return true
+	}
+
+	return isUndefined(inst)
+}
+
+func isUndefined(inst evmops.Instruction) bool {
+	return strings.HasPrefix(inst.Mnemonic, "__UNDEFINED_INSTRUCTION")
+}
@@ -0,0 +1,180 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// CFGSchemaVersion is the version of the JSON representation of control-flow graphs.
+// It is incremented on every incompatible change of the schema.
+const CFGSchemaVersion = 1
+
+type cfgJSON struct {
+	Version int            `json:"version"`
+	Blocks  []cfgBlockJSON `json:"blocks"`
+	Edges   []cfgEdgeJSON  `json:"edges"`
+}
+
+type cfgBlockJSON struct {
+	ID             int                  `json:"id"`
+	Start          uint64               `json:"start"`
+	End            uint64               `json:"end"`
+	Reachable      bool                 `json:"reachable"`
+	UnresolvedJump bool                 `json:"unresolved_jump"`
+	Instructions   []cfgInstructionJSON `json:"instructions"`
+}
+
+type cfgInstructionJSON struct {
+	PC        uint64 `json:"pc"`
+	Op        string `json:"op"`
+	Arg       string `json:"arg,omitempty"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type cfgEdgeJSON struct {
+	From int    `json:"from"`
+	To   int    `json:"to"`
+	Kind string `json:"kind"`
+}
+
+// MarshalJSON encodes the graph into a stable JSON schema:
+//
+//	{
+//	  "version": 1,
+//	  "blocks": [{"id": 0, "start": 0, "end": 2, "reachable": true, "unresolved_jump": false,
+//	              "instructions": [{"pc": 0, "op": "PUSH1", "arg": "0x04", "signature": "..."}]}],
+//	  "edges": [{"from": 0, "to": 2, "kind": "jump"}]
+//	}
+//
+// Blocks are ordered by ID and edges by source, kind and destination,
+// so the same code always produces the same output.
+func (g *CFG) MarshalJSON() ([]byte, error) {
+	out := cfgJSON{
+		Version: CFGSchemaVersion,
+		Blocks:  make([]cfgBlockJSON, 0, len(g.Blocks)),
+		Edges:   make([]cfgEdgeJSON, 0, len(g.Edges)),
+	}
+
+	for _, b := range g.Blocks {
+		block := cfgBlockJSON{
+			ID:             b.ID,
+			Start:          b.Start,
+			End:            b.End,
+			Reachable:      b.Reachable,
+			UnresolvedJump: b.UnresolvedJump,
+			Instructions:   make([]cfgInstructionJSON, 0, len(b.Lines)),
+		}
+
+		for _, line := range b.Lines {
+			block.Instructions = append(block.Instructions, cfgInstructionJSON{
+				PC:        line.ProgramCounter,
+				Op:        line.Inst.Mnemonic,
+				Arg:       lineArgHex(line),
+				Signature: line.BestSignature,
+			})
+		}
+
+		out.Blocks = append(out.Blocks, block)
+	}
+
+	for _, e := range g.Edges {
+		out.Edges = append(out.Edges, cfgEdgeJSON{
+			From: e.From,
+			To:   e.To,
+			Kind: edgeKindJSON(e.Kind),
+		})
+	}
+
+	return json.Marshal(out)
+}
+
+func edgeKindJSON(k EdgeKind) string {
+	return strings.ReplaceAll(k.String(), " ", "_")
+}
+
+// WriteDOT writes the graph in Graphviz DOT format.
+// Blocks are labelled with their instructions and the best signatures of the pushed selectors,
+// unreachable blocks are drawn dashed. The output is deterministic.
+func (g *CFG) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, "digraph cfg {")
+	fmt.Fprintln(bw, "\tnode [shape=box fontname=\"monospace\"];")
+
+	for _, b := range g.Blocks {
+		var label strings.Builder
+		for _, line := range b.Lines {
+			text := fmt.Sprintf("%05x: %s", line.ProgramCounter, line.Inst.Mnemonic)
+			if arg := lineArgHex(line); arg != "" {
+				text += " " + arg
+			}
+			if line.BestSignature != "" {
+				text += " ; " + line.BestSignature
+			}
+			label.WriteString(escapeDOT(text) + `\l`)
+		}
+
+		attrs := ""
+		if !b.Reachable {
+			attrs = " style=dashed color=gray"
+		}
+
+		fmt.Fprintf(bw, "\tb%d [label=\"%s\"%s];\n", b.ID, label.String(), attrs)
+	}
+
+	for _, e := range g.Edges {
+		attrs := ""
+		switch {
+		case e.Kind == EdgeConditionalJump:
+			attrs = ` [label="true" color=darkgreen]`
+		case e.Kind == EdgeFallthrough && g.Blocks[e.From].Terminator().Inst.Code == opJUMPI:
+			attrs = ` [label="false" color=red]`
+		case e.Kind == EdgeFallthrough:
+			attrs = ` [style=dashed]`
+		}
+
+		fmt.Fprintf(bw, "\tb%d -> b%d%s;\n", e.From, e.To, attrs)
+	}
+
+	fmt.Fprintln(bw, "}")
+
+	return bw.Flush()
+}
+
+// escapeDOT escapes a line of a label for a double-quoted DOT string.
+func escapeDOT(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\l`)
+
+	return s
+}
+
+// lineArgHex returns the immediate argument of the line as a 0x-prefixed hex string.
+func lineArgHex(line evmops.Line) string {
+	if len(line.Args) == 0 {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString([]byte(strings.Join(line.Args, "")))
+}
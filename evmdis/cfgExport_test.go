@@ -0,0 +1,105 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestCFG_WriteDOT(t *testing.T) {
+	// PUSH4 0xa9059cbb PUSH1 0x09 JUMPI INVALID JUMPDEST STOP
+	g := BuildCFG(disassembleOffline(t, "63a9059cbb600957fe5b00"))
+
+	var buf bytes.Buffer
+	if err := g.WriteDOT(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	want := strings.Join([]string{
+		`digraph cfg {`,
+		`	node [shape=box fontname="monospace"];`,
+		`	b0 [label="00000: PUSH4 0xa9059cbb ; transfer(address,uint256)\l00005: PUSH1 0x09\l00007: JUMPI\l"];`,
+		`	b1 [label="00008: INVALID\l"];`,
+		`	b2 [label="00009: JUMPDEST\l0000a: STOP\l"];`,
+		`	b0 -> b1 [label="false" color=red];`,
+		`	b0 -> b2 [label="true" color=darkgreen];`,
+		`}`,
+		``,
+	}, "\n")
+
+	if got := buf.String(); got != want {
+		t.Errorf("WriteDOT() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+func TestEscapeDOT(t *testing.T) {
+	tests := []struct {
+		s    string
+		want string
+	}{
+		{s: `transfer(address,uint256)`, want: `transfer(address,uint256)`},
+		{s: `say("hi")`, want: `say(\"hi\")`},
+		{s: `path\l`, want: `path\\l`},
+		{s: `\"`, want: `\\\"`},
+		{s: "a\nb", want: `a\lb`},
+	}
+	for _, tt := range tests {
+		if got := escapeDOT(tt.s); got != tt.want {
+			t.Errorf("escapeDOT(%q) = %q, want %q", tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestCFG_MarshalJSON(t *testing.T) {
+	code := "600456fe5b600035600c57005b00"
+
+	first, err := json.Marshal(BuildCFG(disassembleOffline(t, code)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	second, err := json.Marshal(BuildCFG(disassembleOffline(t, code)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !bytes.Equal(first, second) {
+		t.Errorf("MarshalJSON() is not deterministic:\n%s\n%s", first, second)
+	}
+
+	var got cfgJSON
+	if err := json.Unmarshal(first, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Version != CFGSchemaVersion || len(got.Blocks) != 5 || len(got.Edges) != 3 {
+		t.Fatalf("unexpected graph: %s", first)
+	}
+
+	if b := got.Blocks[1]; b.Reachable || b.Instructions[0].Op != "INVALID" {
+		t.Errorf("unexpected block 1: %+v", b)
+	}
+
+	if e := got.Edges[2]; e.From != 2 || e.To != 4 || e.Kind != "conditional_jump" {
+		t.Errorf("unexpected edge: %+v", e)
+	}
+
+	if arg := got.Blocks[0].Instructions[0].Arg; arg != "0x04" {
+		t.Errorf("unexpected push argument %q", arg)
+	}
+}
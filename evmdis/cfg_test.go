@@ -0,0 +1,141 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func disassembleOffline(t *testing.T, code string) *Results {
+	t.Helper()
+
+	r, err := NewDisassembler(WithSignatureResolver(OfflineSignatureResolver())).Disassemble(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestBuildCFG(t *testing.T) {
+	type block struct {
+		Start, End     uint64
+		Reachable      bool
+		UnresolvedJump bool
+	}
+
+	tests := []struct {
+		name       string
+		code       string
+		wantBlocks []block
+		wantEdges  []Edge
+	}{
+		{
+			name: "static jumps",
+			// PUSH1 4 JUMP INVALID JUMPDEST PUSH1 0 CALLDATALOAD PUSH1 0x0c JUMPI STOP JUMPDEST STOP
+			code: "600456fe5b600035600c57005b00",
+			wantBlocks: []block{
+				{Start: 0x00, End: 0x02, Reachable: true},
+				{Start: 0x03, End: 0x03},
+				{Start: 0x04, End: 0x0a, Reachable: true},
+				{Start: 0x0b, End: 0x0b, Reachable: true},
+				{Start: 0x0c, End: 0x0d, Reachable: true},
+			},
+			wantEdges: []Edge{
+				{From: 0, To: 2, Kind: EdgeJump},
+				{From: 2, To: 3, Kind: EdgeFallthrough},
+				{From: 2, To: 4, Kind: EdgeConditionalJump},
+			},
+		},
+		{
+			name: "dynamic jump",
+			// PUSH1 0 CALLDATALOAD JUMP JUMPDEST STOP STOP
+			code: "600035565b0000",
+			wantBlocks: []block{
+				{Start: 0x00, End: 0x03, Reachable: true, UnresolvedJump: true},
+				{Start: 0x04, End: 0x05, Reachable: true},
+				{Start: 0x06, End: 0x06},
+			},
+		},
+		{
+			name: "jump to non-jumpdest and fallthrough into jumpdest",
+			// PUSH1 5 PUSH1 0 JUMPI PUSH1 1 JUMPDEST STOP
+			code: "6005600057" + "6001" + "5b00",
+			wantBlocks: []block{
+				{Start: 0x00, End: 0x04, Reachable: true},
+				{Start: 0x05, End: 0x05, Reachable: true},
+				{Start: 0x07, End: 0x08, Reachable: true},
+			},
+			wantEdges: []Edge{
+				{From: 0, To: 1, Kind: EdgeFallthrough},
+				{From: 1, To: 2, Kind: EdgeFallthrough},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := BuildCFG(disassembleOffline(t, tt.code))
+
+			var gotBlocks []block
+			for _, b := range g.Blocks {
+				gotBlocks = append(gotBlocks, block{b.Start, b.End, b.Reachable, b.UnresolvedJump})
+			}
+
+			if !reflect.DeepEqual(gotBlocks, tt.wantBlocks) {
+				t.Errorf("BuildCFG() blocks = %+v, want %+v", gotBlocks, tt.wantBlocks)
+			}
+
+			if len(g.Edges) != 0 || len(tt.wantEdges) != 0 {
+				if !reflect.DeepEqual(g.Edges, tt.wantEdges) {
+					t.Errorf("BuildCFG() edges = %+v, want %+v", g.Edges, tt.wantEdges)
+				}
+			}
+		})
+	}
+}
+
+func TestCFG_BlockContaining(t *testing.T) {
+	g := BuildCFG(disassembleOffline(t, "600456fe5b600035600c57005b00"))
+
+	for pc, want := range map[uint64]int{0: 0, 1: 0, 2: 0, 3: 1, 7: 2, 0x0d: 4} {
+		b, ok := g.BlockContaining(pc)
+		if !ok || b.ID != want {
+			t.Errorf("BlockContaining(%d) = %v, %v, want block %d", pc, b, ok, want)
+		}
+	}
+
+	if _, ok := g.BlockContaining(0x0e); ok {
+		t.Errorf("BlockContaining() past the end of code should fail")
+	}
+}
+
+func TestBuildCFG_RealContracts(t *testing.T) {
+	r := disassembleOffline(t, uniswapRuntimeCode)
+	g := BuildCFG(r)
+
+	total := 0
+	for _, b := range g.Blocks {
+		total += len(b.Lines)
+	}
+
+	if total != len(r.Lines) {
+		t.Errorf("blocks contain %d lines, want %d", total, len(r.Lines))
+	}
+
+	if len(g.Blocks) < 100 {
+		t.Errorf("expected many blocks in uniswap token, got %d", len(g.Blocks))
+	}
+}
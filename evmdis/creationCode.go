@@ -0,0 +1,189 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"errors"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+const (
+	opCODECOPY evmops.Opcode = 0x39
+	opDUP16    evmops.Opcode = 0x8f
+	opSWAP1    evmops.Opcode = 0x90
+	opSWAP16   evmops.Opcode = 0x9f
+)
+
+// ErrNotCreationCode is returned by SplitCreationCode if the code does not deploy any runtime code.
+var ErrNotCreationCode = errors.New("evmdis: no runtime code deployment found")
+
+// CreationCode is a contract creation bytecode split into its parts.
+// All the parts are subslices of the original code.
+type CreationCode struct {
+	// InitCode is the constructor code, executed once on deployment
+	InitCode []byte
+
+	// RuntimeCode is the code returned by the constructor, i.e. the code stored on chain.
+	// It includes the compiler metadata, if any.
+	RuntimeCode []byte
+
+	// RuntimeOffset is the offset of the runtime code in the creation code
+	RuntimeOffset int
+
+	// Metadata is the compiler metadata appended to the runtime code, including its 2-byte length suffix.
+	// It is empty if the runtime code has no metadata.
+	Metadata []byte
+
+	// ConstructorArgs are ABI-encoded constructor arguments appended to the creation code
+	ConstructorArgs []byte
+}
+
+// RuntimeCodeWithoutMetadata returns the runtime code with the compiler metadata stripped.
+func (c *CreationCode) RuntimeCodeWithoutMetadata() []byte {
+	return c.RuntimeCode[:len(c.RuntimeCode)-len(c.Metadata)]
+}
+
+// SplitCreationCode detects the runtime code deployment pattern
+//
+//	PUSH size ... PUSH offset PUSH dest CODECOPY ... PUSH dest RETURN
+//
+// in the creation code, and splits it into the init code, runtime code, runtime metadata and constructor arguments.
+// Constant operands are tracked through DUP and SWAP instructions, so both the Solidity and Vyper
+// deployment sequences are recognised.
+func SplitCreationCode(code []byte) (*CreationCode, error) {
+	type codeCopy struct {
+		dest, offset, size uint64
+	}
+
+	var (
+		stack  []*uint64
+		copies []codeCopy
+	)
+
+	pop := func() *uint64 {
+		if len(stack) == 0 {
+			return nil
+		}
+		v := stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		return v
+	}
+
+	for pc := 0; pc < len(code); pc++ {
+		op := evmops.Opcode(code[pc])
+		inst := evmops.InstructionSet[op]
+
+		switch {
+		case op == opJUMPDEST:
+			// a new block is entered from unknown places, so nothing is known about the stack
+			stack = stack[:0]
+
+		case op == opPUSH0:
+			zero := uint64(0)
+			stack = append(stack, &zero)
+
+		case op >= opPUSH1 && op <= opPUSH32:
+			n := int(op-opPUSH1) + 1
+			if pc+n >= len(code) {
+				return nil, ErrNotCreationCode
+			}
+
+			var v *uint64
+			if n <= 8 {
+				x := uint64(0)
+				for _, b := range code[pc+1 : pc+1+n] {
+					x = x<<8 | uint64(b)
+				}
+				v = &x
+			}
+
+			stack = append(stack, v)
+			pc += n
+
+		case op >= opDUP1 && op <= opDUP16:
+			n := int(op-opDUP1) + 1
+			var v *uint64
+			if n <= len(stack) {
+				v = stack[len(stack)-n]
+			}
+			stack = append(stack, v)
+
+		case op >= opSWAP1 && op <= opSWAP16:
+			n := int(op-opSWAP1) + 1
+			if n < len(stack) {
+				top := len(stack) - 1
+				stack[top], stack[top-n] = stack[top-n], stack[top]
+			} else {
+				stack = stack[:0]
+			}
+
+		case op == opCODECOPY:
+			dest, offset, size := pop(), pop(), pop()
+			if dest != nil && offset != nil && size != nil && *size > 0 &&
+				*offset <= uint64(len(code)) && *size <= uint64(len(code))-*offset {
+				copies = append(copies, codeCopy{*dest, *offset, *size})
+			}
+
+		case op == opRETURN:
+			offset, size := pop(), pop()
+			for i := len(copies) - 1; i >= 0; i-- {
+				c := copies[i]
+				if offset != nil && *offset != c.dest {
+					continue
+				}
+
+				if size != nil && *size != c.size {
+					continue
+				}
+
+				return newCreationCode(code, int(c.offset), int(c.offset+c.size)), nil
+			}
+
+		default:
+			for i := 0; i < inst.InCount; i++ {
+				pop()
+			}
+			for i := 0; i < inst.OutCount; i++ {
+				stack = append(stack, nil)
+			}
+		}
+	}
+
+	return nil, ErrNotCreationCode
+}
+
+func newCreationCode(code []byte, start, end int) *CreationCode {
+	runtime := code[start:end]
+
+	return &CreationCode{
+		InitCode:        code[:start],
+		RuntimeCode:     runtime,
+		RuntimeOffset:   start,
+		Metadata:        runtime[len(runtime)-metadataLength(runtime):],
+		ConstructorArgs: code[end:],
+	}
+}
+
+// metadataLength returns the length of the compiler metadata trailer of the code,
+// including the 2-byte length suffix, or 0 if there is no metadata.
+func metadataLength(code []byte) int {
+	md, err := DecodeMetadata(code)
+	if err != nil {
+		return 0
+	}
+
+	return len(md.Raw)
+}
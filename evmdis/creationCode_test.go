@@ -0,0 +1,134 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestSplitCreationCode(t *testing.T) {
+	tests := []struct {
+		name            string
+		code            string
+		wantInitLen     int
+		wantRuntimeLen  int
+		wantMetadataLen int
+		wantArgsLen     int
+		wantErr         error
+	}{
+		{
+			name:            "erc20/usdt",
+			code:            usdtCreationCode,
+			wantInitLen:     569,
+			wantRuntimeLen:  11075,
+			wantMetadataLen: 43,
+			wantArgsLen:     256,
+		},
+		{
+			name:            "erc20/goerli_test_token",
+			code:            goerliTestTokenCreationCode,
+			wantInitLen:     2053,
+			wantRuntimeLen:  7164,
+			wantMetadataLen: 53,
+			wantArgsLen:     224,
+		},
+		{
+			name: "minimal",
+			// PUSH1 2 DUP1 PUSH1 0x0a PUSH0 CODECOPY PUSH0 RETURN INVALID | STOP STOP
+			code:           "6002" + "80" + "600a" + "5f" + "39" + "5f" + "f3" + "fe" + "0000",
+			wantInitLen:    10,
+			wantRuntimeLen: 2,
+		},
+		{
+			name: "overflowing_codecopy",
+			// PUSH1 2 PUSH8 0xffffffffffffffff PUSH1 0 CODECOPY PUSH1 2 PUSH1 0 RETURN
+			code:    "6002" + "67ffffffffffffffff" + "6000" + "39" + "6002" + "6000" + "f3",
+			wantErr: ErrNotCreationCode,
+		},
+		{
+			name:    "erc20/uniswap runtime",
+			code:    uniswapRuntimeCode,
+			wantErr: ErrNotCreationCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := hex.DecodeString(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := SplitCreationCode(code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("SplitCreationCode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if len(got.InitCode) != tt.wantInitLen || len(got.RuntimeCode) != tt.wantRuntimeLen ||
+				len(got.Metadata) != tt.wantMetadataLen || len(got.ConstructorArgs) != tt.wantArgsLen {
+				t.Errorf("SplitCreationCode() = init %d, runtime %d, metadata %d, args %d",
+					len(got.InitCode), len(got.RuntimeCode), len(got.Metadata), len(got.ConstructorArgs))
+			}
+
+			if got.RuntimeOffset != len(got.InitCode) {
+				t.Errorf("RuntimeOffset = %d, want %d", got.RuntimeOffset, len(got.InitCode))
+			}
+		})
+	}
+}
+
+func TestDisassembleRuntimeOfCreationCode(t *testing.T) {
+	code, err := hex.DecodeString(usdtCreationCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts, err := SplitCreationCode(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	d := NewDisassembler(WithSignatureResolver(OfflineSignatureResolver()))
+	runtime, err := d.DisassembleCode(context.Background(), parts.RuntimeCodeWithoutMetadata())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !runtime.Implements(ERC20Iface) {
+		t.Errorf("expected usdt runtime code to implement ERC20")
+	}
+
+	// with the runtime code disassembled on its own, dispatcher targets are real jump destinations
+	g := BuildCFG(runtime)
+	for _, e := range runtime.Dispatcher.Entries {
+		if _, ok := g.jumpDestBlock(e.EntryPC); !ok {
+			t.Errorf("entry of %s at %d is not a jump destination", e.Signature, e.EntryPC)
+		}
+	}
+
+	init, err := d.DisassembleCode(context.Background(), parts.InitCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if init.Dispatcher != nil && len(init.Dispatcher.Entries) > 0 {
+		t.Errorf("expected no dispatcher in init code, got %+v", init.Dispatcher)
+	}
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultDiskCacheTTL is the default lifetime of cached selector lookups.
+	DefaultDiskCacheTTL = 30 * 24 * time.Hour
+
+	// DefaultDiskCacheNegativeTTL is the default lifetime of cached lookups of unknown selectors.
+	DefaultDiskCacheNegativeTTL = 24 * time.Hour
+)
+
+// DiskCacheResolver caches results of another resolver on disk, so they survive process restarts.
+//
+// Each selector is stored in its own file, which is replaced atomically,
+// so a cache directory can be shared by several processes running in parallel.
+// Errors of the underlying resolver are never cached.
+type DiskCacheResolver struct {
+	next SignatureResolver
+	dir  string
+
+	// TTL is the lifetime of non-empty results.
+	TTL time.Duration
+
+	// NegativeTTL is the lifetime of empty results, i.e. unknown selectors.
+	// Negative results are not cached if NegativeTTL is negative.
+	NegativeTTL time.Duration
+
+	now func() time.Time
+}
+
+type diskCacheEntry struct {
+	FetchedAt  time.Time `json:"fetched_at"`
+	Signatures []string  `json:"signatures"`
+}
+
+// NewDiskCacheResolver returns a resolver caching results of next in dir, creating dir if needed.
+// Zero ttl and negativeTTL are replaced with DefaultDiskCacheTTL and DefaultDiskCacheNegativeTTL.
+func NewDiskCacheResolver(next SignatureResolver, dir string, ttl, negativeTTL time.Duration) (*DiskCacheResolver, error) {
+	if ttl == 0 {
+		ttl = DefaultDiskCacheTTL
+	}
+
+	if negativeTTL == 0 {
+		negativeTTL = DefaultDiskCacheNegativeTTL
+	}
+
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+
+	return &DiskCacheResolver{
+		next:        next,
+		dir:         dir,
+		TTL:         ttl,
+		NegativeTTL: negativeTTL,
+		now:         time.Now,
+	}, nil
+}
+
+func (c *DiskCacheResolver) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	if err := checkSelector(selector); err != nil {
+		return nil, err
+	}
+
+	path := c.entryPath(selector)
+	if entry, ok := c.read(path); ok {
+		return entry.Signatures, nil
+	}
+
+	sigs, err := c.next.Lookup(ctx, selector)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(sigs) == 0 && c.NegativeTTL < 0 {
+		return sigs, nil
+	}
+
+	// a failed cache write must not fail the lookup itself, the next lookup will just try again
+	_ = c.write(path, &diskCacheEntry{
+		FetchedAt:  c.now().UTC(),
+		Signatures: sigs,
+	})
+
+	return sigs, nil
+}
+
+// NewestFirst returns true if the underlying resolver returns the newest signatures first.
+func (c *DiskCacheResolver) NewestFirst() bool {
+	return isNewestFirst(c.next)
+}
+
+// Prune removes expired and unreadable cache entries.
+func (c *DiskCacheResolver) Prune() error {
+	return filepath.WalkDir(c.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if d.IsDir() || !strings.HasSuffix(path, ".json") {
+			return nil
+		}
+
+		if _, ok := c.read(path); ok {
+			return nil
+		}
+
+		if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+
+		return nil
+	})
+}
+
+func (c *DiskCacheResolver) entryPath(selector []byte) string {
+	hexSelector := hex.EncodeToString(selector)
+
+	return filepath.Join(c.dir, hexSelector[:2], hexSelector+".json")
+}
+
+// read returns the cache entry stored at path if it exists, is readable and is not expired yet.
+func (c *DiskCacheResolver) read(path string) (*diskCacheEntry, bool) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry diskCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, false
+	}
+
+	ttl := c.TTL
+	if len(entry.Signatures) == 0 {
+		ttl = c.NegativeTTL
+	}
+
+	if c.now().Sub(entry.FetchedAt) >= ttl {
+		return nil, false
+	}
+
+	return &entry, true
+}
+
+// write atomically replaces the cache entry at path,
+// so concurrent readers never observe partially written files.
+func (c *DiskCacheResolver) write(path string, entry *diskCacheEntry) error {
+	if entry.Signatures == nil {
+		entry.Signatures = []string{}
+	}
+
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Chmod(0o644); err != nil {
+		tmp.Close()
+		return err
+	}
+
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	return os.Rename(tmp.Name(), path)
+}
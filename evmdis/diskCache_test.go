@@ -0,0 +1,105 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDiskCacheResolver(t *testing.T) {
+	ctx := context.Background()
+	known := []byte{0xa9, 0x05, 0x9c, 0xbb}
+	unknown := []byte{0xde, 0xad, 0xbe, 0xef}
+	failing := []byte{0x00, 0x00, 0x00, 0x01}
+
+	var calls int32
+	next := SignatureResolverFunc(func(ctx context.Context, selector []byte) ([]string, error) {
+		atomic.AddInt32(&calls, 1)
+		switch {
+		case reflect.DeepEqual(selector, known):
+			return []string{"transfer(address,uint256)"}, nil
+		case reflect.DeepEqual(selector, failing):
+			return nil, errors.New("unavailable")
+		}
+		return nil, nil
+	})
+
+	now := time.Date(2022, 11, 1, 0, 0, 0, 0, time.UTC)
+	dir := t.TempDir()
+	newCache := func() *DiskCacheResolver {
+		c, err := NewDiskCacheResolver(next, dir, time.Hour, time.Minute)
+		if err != nil {
+			t.Fatal(err)
+		}
+		c.now = func() time.Time { return now }
+		return c
+	}
+
+	lookup := func(c *DiskCacheResolver, selector []byte, wantCalls int32) []string {
+		t.Helper()
+
+		before := atomic.LoadInt32(&calls)
+		got, _ := c.Lookup(ctx, selector)
+		if n := atomic.LoadInt32(&calls) - before; n != wantCalls {
+			t.Errorf("Lookup(%x) made %d calls to the underlying resolver, want %d", selector, n, wantCalls)
+		}
+		return got
+	}
+
+	c := newCache()
+	if got := lookup(c, known, 1); !reflect.DeepEqual(got, []string{"transfer(address,uint256)"}) {
+		t.Errorf("Lookup() = %v", got)
+	}
+	lookup(c, unknown, 1)
+	lookup(c, failing, 1)
+
+	// another process sharing the directory
+	c = newCache()
+	if got := lookup(c, known, 0); !reflect.DeepEqual(got, []string{"transfer(address,uint256)"}) {
+		t.Errorf("Lookup() = %v", got)
+	}
+	lookup(c, unknown, 0)
+	lookup(c, failing, 1)
+
+	// negative results expire sooner
+	now = now.Add(2 * time.Minute)
+	lookup(c, known, 0)
+	lookup(c, unknown, 1)
+
+	now = now.Add(2 * time.Hour)
+	if err := c.Prune(); err != nil {
+		t.Fatal(err)
+	}
+	lookup(c, known, 1)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			got, err := newCache().Lookup(ctx, known)
+			if err != nil || len(got) != 1 {
+				t.Errorf("concurrent Lookup() = %v, %v", got, err)
+			}
+		}()
+	}
+	wg.Wait()
+}
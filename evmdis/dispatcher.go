@@ -0,0 +1,291 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/kirillDanshin/evmtools"
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Opcodes used by function dispatchers.
+const (
+	opEQ           evmops.Opcode = 0x14
+	opISZERO       evmops.Opcode = 0x15
+	opXOR          evmops.Opcode = 0x18
+	opDIV          evmops.Opcode = 0x04
+	opSHR          evmops.Opcode = 0x1c
+	opCALLDATALOAD evmops.Opcode = 0x35
+	opMLOAD        evmops.Opcode = 0x51
+	opMSTORE       evmops.Opcode = 0x52
+	opPUSH0        evmops.Opcode = 0x5f
+	opPUSH4        evmops.Opcode = 0x63
+	opDUP1         evmops.Opcode = 0x80
+	opDUP2         evmops.Opcode = 0x81
+)
+
+// DispatcherKind is the kind of the function selector extraction code.
+type DispatcherKind uint8
+
+const (
+	// DispatcherUnknown means that no selector extraction was found.
+	DispatcherUnknown DispatcherKind = iota
+
+	// DispatcherShift is the selector extraction using `CALLDATALOAD; PUSH1 0xe0; SHR`,
+	// emitted by Solidity since 0.5 and by modern Vyper.
+	DispatcherShift
+
+	// DispatcherDiv is the selector extraction using division by 2**224,
+	// emitted by Solidity before Constantinople.
+	DispatcherDiv
+
+	// DispatcherVyperMemory is the selector extraction of old Vyper versions,
+	// that store the calldata word at memory offset 28 and read the selector with MLOAD(0).
+	DispatcherVyperMemory
+)
+
+func (k DispatcherKind) String() string {
+	switch k {
+	case DispatcherUnknown:
+		return "unknown"
+	case DispatcherShift:
+		return "shift"
+	case DispatcherDiv:
+		return "div"
+	case DispatcherVyperMemory:
+		return "vyper memory"
+	default:
+		return "invalid"
+	}
+}
+
+// DispatchEntry is an external function of the contract found in the dispatcher.
+type DispatchEntry struct {
+	Selector [4]byte
+
+	// EntryPC is the program counter the dispatcher jumps to for the selector.
+	// Jump destinations are relative to the start of the code segment containing the dispatcher,
+	// so for creation code they are relative to the start of the runtime code.
+	EntryPC uint64
+
+	// Signature is the best ranked signature of the selector, if resolved
+	Signature string
+}
+
+// SelectorHex returns the selector as a hex string without 0x prefix.
+func (e DispatchEntry) SelectorHex() string {
+	return hex.EncodeToString(e.Selector[:])
+}
+
+// DispatchTable maps function selectors to their entry points.
+type DispatchTable struct {
+	Kind DispatcherKind
+
+	// SelectorPC is the program counter of CALLDATALOAD reading the selector
+	SelectorPC uint64
+
+	// Entries are sorted by selector
+	Entries []DispatchEntry
+}
+
+// Lookup returns the dispatch entry of the selector.
+func (t *DispatchTable) Lookup(selector []byte) (DispatchEntry, bool) {
+	i := sort.Search(len(t.Entries), func(i int) bool {
+		return bytes.Compare(t.Entries[i].Selector[:], selector) >= 0
+	})
+	if i < len(t.Entries) && bytes.Equal(t.Entries[i].Selector[:], selector) {
+		return t.Entries[i], true
+	}
+
+	return DispatchEntry{}, false
+}
+
+// LookupSignature returns the dispatch entry of the function with the given canonical signature.
+func (t *DispatchTable) LookupSignature(sig string) (DispatchEntry, bool) {
+	return t.Lookup(evmtools.MethodID(sig))
+}
+
+// ExtractDispatcher recognises Solidity and Vyper function dispatchers and returns the selector to entry PC table.
+//
+// The following comparisons of the extracted selector are recognised:
+//
+//	DUP1 PUSH4 x EQ PUSH2 dest JUMPI         ; Solidity
+//	PUSH4 x DUP2 EQ PUSH2 dest JUMPI         ; Solidity, optimized
+//	PUSH4 x DUP2 XOR PUSH2 skip JUMPI        ; Vyper, the function follows JUMPI
+//	PUSH4 x PUSH1 0 MLOAD EQ ISZERO PUSH2 skip JUMPI ; old Vyper
+//
+// Binary search splits (GT/LT comparisons of the selector) are not entries and are skipped,
+// as are selectors pushed for other purposes, e.g. to call other contracts.
+// ExtractDispatcher returns nil if there is no selector extraction in the code.
+func ExtractDispatcher(r *Results) *DispatchTable {
+	kind, selectorPC, start, ok := findSelectorExtraction(r.Lines)
+	if !ok {
+		return nil
+	}
+
+	t := &DispatchTable{
+		Kind:       kind,
+		SelectorPC: selectorPC,
+	}
+
+	seen := map[[4]byte]struct{}{}
+	for i := start; i < len(r.Lines); i++ {
+		line := r.Lines[i]
+		if line.Inst.Code != opPUSH4 || len(line.Args) != 1 || len(line.Args[0]) != 4 {
+			continue
+		}
+
+		entryPC, ok := matchSelectorComparison(r.Lines, i)
+		if !ok {
+			continue
+		}
+
+		var sel [4]byte
+		copy(sel[:], line.Args[0])
+		if _, ok := seen[sel]; ok {
+			continue
+		}
+		seen[sel] = struct{}{}
+
+		t.Entries = append(t.Entries, DispatchEntry{
+			Selector:  sel,
+			EntryPC:   entryPC,
+			Signature: line.BestSignature,
+		})
+	}
+
+	sort.Slice(t.Entries, func(i, j int) bool {
+		return bytes.Compare(t.Entries[i].Selector[:], t.Entries[j].Selector[:]) < 0
+	})
+
+	return t
+}
+
+// findSelectorExtraction finds `PUSH 0; CALLDATALOAD` followed by one of the known selector extraction sequences.
+// It returns the kind of the extraction, the CALLDATALOAD program counter and the index of the next line.
+func findSelectorExtraction(lines []evmops.Line) (DispatcherKind, uint64, int, bool) {
+	for i := 1; i < len(lines); i++ {
+		if lines[i].Inst.Code != opCALLDATALOAD || !isPushOf(lines[i-1], 0) {
+			continue
+		}
+
+		const window = 6
+		for j := i + 1; j < len(lines) && j <= i+window; j++ {
+			switch lines[j].Inst.Code {
+			case opSHR:
+				if isPushOf(lines[j-1], 0xe0) {
+					return DispatcherShift, lines[i].ProgramCounter, j + 1, true
+				}
+			case opDIV:
+				if isDivisorPushed(lines[i+1 : j]) {
+					return DispatcherDiv, lines[i].ProgramCounter, j + 1, true
+				}
+			case opMSTORE:
+				if isPushOf(lines[j-1], 0x1c) {
+					return DispatcherVyperMemory, lines[i].ProgramCounter, j + 1, true
+				}
+			}
+		}
+	}
+
+	return DispatcherUnknown, 0, 0, false
+}
+
+// isDivisorPushed returns true if 2**224 is pushed in the given lines.
+func isDivisorPushed(lines []evmops.Line) bool {
+	for _, line := range lines {
+		if line.Inst.Code != opPUSH1+28 || len(line.Args) != 1 {
+			continue
+		}
+
+		arg := []byte(line.Args[0])
+		if len(arg) == 29 && arg[0] == 1 && bytes.Count(arg[1:], []byte{0}) == 28 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// matchSelectorComparison matches a comparison of the selector pushed at lines[i] followed by a conditional jump,
+// and returns the program counter of the function entry.
+func matchSelectorComparison(lines []evmops.Line, i int) (uint64, bool) {
+	j := i + 1
+	at := func(k int) (evmops.Opcode, bool) {
+		if k >= len(lines) {
+			return 0, false
+		}
+		return lines[k].Inst.Code, true
+	}
+
+	// the selector is either duplicated before the PUSH4 or after it, or loaded from memory by old Vyper
+	switch op, _ := at(j); {
+	case op == opDUP2:
+		j++
+	case j < len(lines) && isPushOf(lines[j], 0):
+		if op, ok := at(j + 1); !ok || op != opMLOAD {
+			return 0, false
+		}
+		j += 2
+	case i == 0 || lines[i-1].Inst.Code != opDUP1:
+		return 0, false
+	}
+
+	op, ok := at(j)
+	if !ok || (op != opEQ && op != opXOR) {
+		return 0, false
+	}
+	notEqual := op == opXOR
+	j++
+
+	if op, ok := at(j); ok && op == opISZERO {
+		notEqual = !notEqual
+		j++
+	}
+
+	if j+1 >= len(lines) || lines[j+1].Inst.Code != opJUMPI {
+		return 0, false
+	}
+
+	dest, ok := pushValue(lines[j])
+	if !ok {
+		return 0, false
+	}
+
+	if notEqual {
+		// the function body follows the jump over it
+		if j+2 >= len(lines) {
+			return 0, false
+		}
+
+		return lines[j+2].ProgramCounter, true
+	}
+
+	return dest, true
+}
+
+// isPushOf returns true if the line pushes the given value.
+func isPushOf(line evmops.Line, v uint64) bool {
+	if line.Inst.Code == opPUSH0 {
+		return v == 0
+	}
+
+	got, ok := pushValue(line)
+
+	return ok && got == v
+}
@@ -0,0 +1,116 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractDispatcher(t *testing.T) {
+	transfer := [4]byte{0xa9, 0x05, 0x9c, 0xbb}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantKind DispatcherKind
+		want     []DispatchEntry
+	}{
+		{
+			name: "solidity",
+			// PUSH1 0x80 PUSH1 0x40 MSTORE PUSH1 0 CALLDATALOAD PUSH1 0xe0 SHR
+			// DUP1 PUSH4 0xa9059cbb EQ PUSH1 0x19 JUMPI PUSH1 0 DUP1 REVERT
+			// JUMPDEST PUSH4 0x18160ddd PUSH1 0xe0 SHL PUSH1 0 MSTORE STOP
+			code:     "6080604052" + "60003560e01c" + "8063a9059cbb14601957" + "600080fd" + "5b" + "6318160ddd60e01b600052" + "00",
+			wantKind: DispatcherShift,
+			want: []DispatchEntry{
+				{Selector: transfer, EntryPC: 0x19, Signature: "transfer(address,uint256)"},
+			},
+		},
+		{
+			name: "vyper",
+			// PUSH1 0 CALLDATALOAD PUSH1 0xe0 SHR PUSH4 0xa9059cbb DUP2 XOR PUSH1 0x11 JUMPI STOP JUMPDEST STOP
+			code:     "60003560e01c" + "63a9059cbb8118601157" + "00" + "5b00",
+			wantKind: DispatcherShift,
+			want: []DispatchEntry{
+				{Selector: transfer, EntryPC: 0x10, Signature: "transfer(address,uint256)"},
+			},
+		},
+		{
+			name: "legacy div",
+			// PUSH1 0 CALLDATALOAD PUSH29 2**224 SWAP1 DIV PUSH4 0xffffffff AND
+			// DUP1 PUSH4 0xa9059cbb EQ PUSH1 0x34 JUMPI STOP JUMPDEST STOP
+			code:     "600035" + "7c01" + "00000000000000000000000000000000000000000000000000000000" + "900463ffffffff16" + "8063a9059cbb14603457" + "00" + "5b00",
+			wantKind: DispatcherDiv,
+			want: []DispatchEntry{
+				{Selector: transfer, EntryPC: 0x34, Signature: "transfer(address,uint256)"},
+			},
+		},
+		{
+			name: "binary search split",
+			// PUSH1 0 CALLDATALOAD PUSH1 0xe0 SHR DUP1 PUSH4 0x70a08231 GT PUSH1 0x1c JUMPI
+			// DUP1 PUSH4 0xa9059cbb EQ PUSH1 0x1c JUMPI STOP JUMPDEST STOP
+			code:     "60003560e01c" + "806370a0823111601c57" + "8063a9059cbb14601c57" + "00" + "5b00",
+			wantKind: DispatcherShift,
+			want: []DispatchEntry{
+				{Selector: transfer, EntryPC: 0x1c, Signature: "transfer(address,uint256)"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractDispatcher(disassembleOffline(t, tt.code))
+			if got == nil {
+				t.Fatal("ExtractDispatcher() = nil")
+			}
+
+			if got.Kind != tt.wantKind {
+				t.Errorf("ExtractDispatcher() kind = %v, want %v", got.Kind, tt.wantKind)
+			}
+
+			if !reflect.DeepEqual(got.Entries, tt.want) {
+				t.Errorf("ExtractDispatcher() entries = %+v, want %+v", got.Entries, tt.want)
+			}
+		})
+	}
+
+	t.Run("no dispatcher", func(t *testing.T) {
+		// PUSH4 0xa9059cbb PUSH1 0 MSTORE STOP
+		if got := ExtractDispatcher(disassembleOffline(t, "63a9059cbb60005200")); got != nil {
+			t.Errorf("ExtractDispatcher() = %+v, want nil", got)
+		}
+	})
+}
+
+func TestResults_ImplementsUsesDispatcher(t *testing.T) {
+	r := disassembleOffline(t, uniswapRuntimeCode)
+	if r.Dispatcher == nil || len(r.Dispatcher.Entries) == 0 {
+		t.Fatal("expected dispatcher to be found")
+	}
+
+	if !r.Implements(ERC20Iface) {
+		t.Errorf("expected uniswap token to implement ERC20")
+	}
+
+	// selectors pushed outside of the dispatcher are found, but are not functions of the contract
+	r = disassembleOffline(t, "6080604052"+"60003560e01c"+"8063a9059cbb14601957"+"600080fd"+"5b"+"6318160ddd60e01b600052"+"00")
+	if _, ok := r.FoundSignatures["totalSupply()"]; !ok {
+		t.Fatal("expected totalSupply() in found signatures")
+	}
+
+	if r.Implements([]string{"totalSupply()"}) {
+		t.Errorf("totalSupply() is not dispatched, but Implements returned true")
+	}
+}
@@ -16,26 +16,96 @@ package evmdis
 
 import (
 	"bytes"
+	"context"
 	"encoding/hex"
+	"fmt"
+	"strconv"
 	"strings"
 
-	"github.com/ethereum/go-ethereum/core/asm"
-	"github.com/ethereum/go-ethereum/core/vm"
-
 	"github.com/kirillDanshin/evmtools/evmops"
 )
 
 type Disassembler struct {
+	resolver SignatureResolver
+	calldata map[string][][]byte
+	fork     evmops.Fork
+}
+
+// Option configures a Disassembler.
+type Option func(*Disassembler)
+
+// WithSignatureResolver sets the resolver used to look up signatures of pushed 4-byte selectors.
+func WithSignatureResolver(r SignatureResolver) Option {
+	return func(d *Disassembler) {
+		d.resolver = r
+	}
 }
 
-func NewDisassembler() *Disassembler {
-	return &Disassembler{}
+// WithCalldata adds calldata samples of calls to the disassembled contract.
+// They are used to cross-check candidate signatures of the selectors when ranking them.
+func WithCalldata(samples ...[]byte) Option {
+	return func(d *Disassembler) {
+		if d.calldata == nil {
+			d.calldata = map[string][][]byte{}
+		}
+
+		for _, data := range samples {
+			if len(data) >= 4 {
+				key := hex.EncodeToString(data[:4])
+				d.calldata[key] = append(d.calldata[key], data)
+			}
+		}
+	}
+}
+
+// WithFork sets the fork the code is disassembled for, the latest fork by default.
+// Instructions that are not active in the fork are disassembled as undefined
+// and reported in the Results.Diagnostics.
+func WithFork(f evmops.Fork) Option {
+	return func(d *Disassembler) {
+		d.fork = f
+	}
+}
+
+func NewDisassembler(opts ...Option) *Disassembler {
+	d := &Disassembler{
+		fork: evmops.LatestFork,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+func (d *Disassembler) signatureResolver() SignatureResolver {
+	if d.resolver == nil {
+		return DefaultSignatureResolver()
+	}
+
+	return d.resolver
 }
 
 type Results struct {
-	CompiledLen     int
-	Lines           []evmops.Line
+	CompiledLen int
+	Lines       []evmops.Line
+
+	// FoundSignatures is the set of the best ranked signatures of the pushed 4-byte selectors.
+	// All the scored candidates are available in the Signatures of the corresponding PUSH4 lines.
 	FoundSignatures map[string]struct{}
+
+	// Dispatcher is the function dispatcher table, nil if no dispatcher was found.
+	Dispatcher *DispatchTable
+
+	// Metadata is the compiler metadata found at the end of the code, nil if there is none.
+	// The metadata is not disassembled.
+	Metadata *Metadata
+
+	// Diagnostics are the problems found in the code, ordered by program counter.
+	Diagnostics []Diagnostic
+
+	// Fork is the fork the code was disassembled for
+	Fork evmops.Fork
 }
 
 func (r *Results) String() string {
@@ -68,9 +138,11 @@ var ERC721Iface = []string{
 	"isApprovedForAll(address,address)",
 }
 
+// Implements returns true if the contract has all the functions with the given canonical signatures.
+// Functions are looked up in the dispatcher table if it is found, otherwise in FoundSignatures.
 func (r *Results) Implements(iface []string) bool {
 	for _, sig := range iface {
-		if _, ok := r.FoundSignatures[sig]; !ok {
+		if !r.hasFunction(sig) {
 			return false
 		}
 	}
@@ -78,88 +150,179 @@ func (r *Results) Implements(iface []string) bool {
 	return true
 }
 
+// hasFunction returns true if the contract has the function with the given canonical signature.
+func (r *Results) hasFunction(sig string) bool {
+	if r.Dispatcher != nil && len(r.Dispatcher.Entries) > 0 {
+		_, ok := r.Dispatcher.LookupSignature(sig)
+		return ok
+	}
+
+	_, ok := r.FoundSignatures[sig]
+
+	return ok
+}
+
 // Disassemble disassembles the given bytecode and returns the disassembled code as lines.
-// It will return results even if the bytecode is invalid,
-// for cases like ENS, where the geth's asm package fails to disassemble the bytecode,
-// but it the results still are sufficient to determine that the ENS contract supports ERC721.
+// Malformed code is not an error: truncated PUSH data, undefined opcodes and the compiler metadata
+// are reported in Results.Diagnostics, and all the decodable instructions are returned.
+// The error is only returned for invalid hex input.
 func (d *Disassembler) Disassemble(code string) (*Results, error) {
+	return d.DisassembleContext(context.Background(), code)
+}
+
+// DisassembleContext is like Disassemble, but passes ctx to the signature resolver.
+// Failed signature lookups are not fatal, the selector is left unresolved instead.
+func (d *Disassembler) DisassembleContext(ctx context.Context, code string) (*Results, error) {
 	script, err := hex.DecodeString(code)
 	if err != nil {
 		return nil, err
 	}
 
+	return d.DisassembleCode(ctx, script)
+}
+
+// DisassembleCode is like DisassembleContext, but accepts raw bytecode,
+// e.g. one of the parts returned by SplitCreationCode.
+func (d *Disassembler) DisassembleCode(ctx context.Context, script []byte) (*Results, error) {
+	resolver := d.signatureResolver()
+	newestFirst := isNewestFirst(resolver)
+	compiledLen := len(script)
+
+	metadata, err := DecodeMetadata(script)
+	if err == nil {
+		script = script[:metadata.Offset]
+	} else {
+		metadata = nil
+	}
+
+	var diagnostics []Diagnostic
 	sigs := map[string]struct{}{}
-	fourBytesToSigs := map[string][]string{}
+	rankedSigs := map[string][]ScoredSignature{}
 	lines := make([]evmops.Line, 0)
 
-	it := asm.NewInstructionIterator(script)
+	// undefined is the diagnostic of the current run of undefined opcodes
+	var undefined *Diagnostic
+	undefinedCount := 0
+	flushUndefined := func() {
+		if undefined != nil {
+			undefined.Reason = fmt.Sprintf("%d undefined opcodes, likely data", undefinedCount)
+			diagnostics = append(diagnostics, *undefined)
+			undefined, undefinedCount = nil, 0
+		}
+	}
+
+	instructions := evmops.ForkInstructionSet(d.fork)
+
+	it := NewInstructionIterator(script)
 	for it.Next() {
-		if it.Arg() != nil && 0 < len(it.Arg()) {
-			if len(it.Arg()) == 4 && it.Op() == vm.PUSH4 {
-				comment := ""
-				hexArg := hex.EncodeToString(it.Arg())
-				if _, ok := fourBytesToSigs[hexArg]; !ok {
-					signatures := getSigsFromFourBytes(it.Arg())
-					for _, sig := range signatures {
-						sigs[sig] = struct{}{}
-						fourBytesToSigs[hexArg] = append(fourBytesToSigs[hexArg], sig)
-						comment += " " + sig
-					}
-				} else {
-					comment = strings.Join(fourBytesToSigs[hexArg], ", ")
-				}
-
-				lines = append(lines, evmops.Line{
-					Inst:           evmops.InstructionSet[it.Op()],
-					Args:           []string{string(it.Arg())},
-					ProgramCounter: it.PC(),
-					Comment:        comment,
-				})
-				continue
+		line := evmops.Line{
+			Inst:           instructions[it.Op()],
+			ProgramCounter: it.PC(),
+		}
+
+		latest := evmops.InstructionSet[it.Op()]
+		switch {
+		case !isUndefined(line.Inst):
+			flushUndefined()
+
+		case !isUndefined(latest):
+			flushUndefined()
+			diagnostics = append(diagnostics, Diagnostic{
+				PC:     it.PC(),
+				Kind:   DiagnosticInactiveOpcode,
+				Reason: fmt.Sprintf("%s is not active before %s", latest.Mnemonic, evmops.IntroducedIn(it.Op())),
+			})
+
+		default:
+			if undefined == nil {
+				undefined = &Diagnostic{PC: it.PC(), Kind: DiagnosticUndefinedOpcode}
+			}
+			undefinedCount++
+		}
+
+		if it.Truncated() {
+			diagnostics = append(diagnostics, Diagnostic{
+				PC:   it.PC(),
+				Kind: DiagnosticTruncatedPush,
+				Reason: fmt.Sprintf("%s needs %d bytes of data, %d available",
+					line.Inst.Mnemonic, pushSize(it.Op()), len(it.Arg())),
+			})
+		}
+
+		if len(it.Arg()) > 0 {
+			line.Args = []string{string(it.Arg())}
+		}
+
+		switch {
+		case it.Op() == opPUSH4 && len(it.Arg()) == 4:
+			hexArg := hex.EncodeToString(it.Arg())
+			ranked, ok := rankedSigs[hexArg]
+			if !ok {
+				signatures, _ := resolver.Lookup(ctx, it.Arg())
+				ranked = rankSignatures(it.Arg(), signatures, newestFirst, d.calldata[hexArg])
+				rankedSigs[hexArg] = ranked
 			}
 
-			if it.Op() == vm.PUSH32 {
-				ascii := ""
-				pos := bytes.IndexByte(it.Arg(), 0)
-				ok := true
-				for i := 0; i < pos; i++ {
-					ok = ok && it.Arg()[i] < 0x80
-				}
-				if ok {
-					ascii = string(it.Arg())
-					if pos >= 0 {
-						ascii = ascii[:pos]
-					}
-				}
-
-				hexArg := hex.EncodeToString([]byte(ascii))
-
-				lines = append(lines, evmops.Line{
-					Inst:           evmops.InstructionSet[it.Op()],
-					Args:           []string{hexArg},
-					ProgramCounter: it.PC(),
-				})
-				continue
+			best := BestSignature(ranked)
+			if best != "" {
+				sigs[best] = struct{}{}
 			}
+
+			comment := make([]string, 0, len(ranked))
+			for _, s := range ranked {
+				comment = append(comment, s.Signature)
+			}
+
+			line.Comment = strings.Join(comment, ", ")
+			line.BestSignature = best
+			line.Signatures = ranked
+
+		case it.Op() == opPUSH32:
+			line.Comment = asciiComment(it.Arg())
 		}
 
-		lines = append(lines, evmops.Line{
-			Inst:           evmops.InstructionSet[it.Op()],
-			ProgramCounter: it.PC(),
-		})
+		lines = append(lines, line)
 	}
+	flushUndefined()
 
-	if err := it.Error(); err != nil {
-		return &Results{
-			Lines:           lines,
-			FoundSignatures: sigs,
-			CompiledLen:     hex.DecodedLen(len(code)),
-		}, err
+	if metadata != nil {
+		diagnostics = append(diagnostics, Diagnostic{
+			PC:     uint64(metadata.Offset),
+			Kind:   DiagnosticMetadata,
+			Reason: fmt.Sprintf("%d bytes of compiler metadata are not disassembled", len(metadata.Raw)),
+		})
 	}
 
-	return &Results{
+	results := &Results{
 		Lines:           lines,
 		FoundSignatures: sigs,
-		CompiledLen:     hex.DecodedLen(len(code)),
-	}, nil
+		CompiledLen:     compiledLen,
+		Metadata:        metadata,
+		Diagnostics:     diagnostics,
+		Fork:            d.fork,
+	}
+	results.Dispatcher = ExtractDispatcher(results)
+
+	return results, nil
+}
+
+// asciiComment returns the quoted text of a pushed word holding a short string, e.g. a revert reason,
+// or an empty string if the word is not a text.
+func asciiComment(arg []byte) string {
+	end := bytes.IndexByte(arg, 0)
+	if end < 0 {
+		end = len(arg)
+	}
+
+	if end == 0 || bytes.Count(arg[end:], []byte{0}) != len(arg)-end {
+		return ""
+	}
+
+	for _, b := range arg[:end] {
+		if b < 0x20 || b >= 0x7f {
+			return ""
+		}
+	}
+
+	return strconv.Quote(string(arg[:end]))
 }
@@ -0,0 +1,312 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Formatter writes disassembly results as text.
+// Formatters must not modify the results.
+type Formatter interface {
+	Format(w io.Writer, r *Results) error
+}
+
+// FormatterFunc is a function implementing Formatter.
+type FormatterFunc func(w io.Writer, r *Results) error
+
+func (f FormatterFunc) Format(w io.Writer, r *Results) error {
+	return f(w, r)
+}
+
+// Format returns the results formatted with f.
+func (r *Results) Format(f Formatter) (string, error) {
+	var buf strings.Builder
+	if err := f.Format(&buf, r); err != nil {
+		return "", err
+	}
+
+	return buf.String(), nil
+}
+
+// ListingFormatter writes the lines as evmops.Line.String does, one per line:
+//
+//	PUSH4 a9059cbb ; transfer(address,uint256) pc=28
+//
+// It is the format of Results.String, which evmasm.Assembler.AssembleListing reads.
+type ListingFormatter struct{}
+
+func (ListingFormatter) Format(w io.Writer, r *Results) error {
+	bw := bufio.NewWriter(w)
+	for i := range r.Lines {
+		bw.WriteString(r.Lines[i].String())
+		bw.WriteByte('\n')
+	}
+
+	return bw.Flush()
+}
+
+// GethFormatter writes the output of the geth's `evm disasm` command:
+//
+//	00000: PUSH1 0x80
+//	0000c: opcode 0xc not defined
+type GethFormatter struct{}
+
+// gethMnemonics are the mnemonics geth names differently.
+var gethMnemonics = map[evmops.Opcode]string{
+	0x20: "KECCAK256",
+	0x44: "DIFFICULTY",
+}
+
+func (GethFormatter) Format(w io.Writer, r *Results) error {
+	bw := bufio.NewWriter(w)
+	for _, line := range r.Lines {
+		mnemonic := line.Inst.Mnemonic
+		if name, ok := gethMnemonics[line.Inst.Code]; ok {
+			mnemonic = name
+		} else if isUndefined(line.Inst) {
+			mnemonic = fmt.Sprintf("opcode %#x not defined", int(line.Inst.Code))
+		}
+
+		if arg := lineArg(line); len(arg) > 0 {
+			fmt.Fprintf(bw, "%05x: %s %#x\n", line.ProgramCounter, mnemonic, arg)
+		} else {
+			fmt.Fprintf(bw, "%05x: %s\n", line.ProgramCounter, mnemonic)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// AsmFormatter writes etk and huff style assembly, that evmasm.Assembler.Assemble reads back
+// into the same bytecode:
+//
+//	transfer:
+//	    jumpdest
+//	    push2 @loc_0123
+//	    jumpi
+//	    push4 0xa9059cbb ; transfer(address,uint256)
+//
+// Static jump destinations are replaced with labels, the function entries from the dispatcher table
+// are labelled with the function names. Undefined instructions and truncated PUSH data are written
+// as .data directives.
+type AsmFormatter struct{}
+
+func (AsmFormatter) Format(w io.Writer, r *Results) error {
+	labels := functionLabels(r)
+
+	jumpDests := map[uint64]bool{}
+	for _, line := range r.Lines {
+		if line.Inst.Code == opJUMPDEST {
+			jumpDests[line.ProgramCounter] = true
+		}
+	}
+
+	// refs maps the indexes of PUSH lines of static jump destinations to the destinations
+	refs := map[int]uint64{}
+	for i := 0; i+1 < len(r.Lines); i++ {
+		next := r.Lines[i+1].Inst.Code
+		if next != opJUMP && next != opJUMPI {
+			continue
+		}
+
+		if dest, ok := pushValue(r.Lines[i]); ok && jumpDests[dest] {
+			refs[i] = dest
+			if _, ok := labels[dest]; !ok {
+				labels[dest] = fmt.Sprintf("loc_%04x", dest)
+			}
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for i, line := range r.Lines {
+		if label, ok := labels[line.ProgramCounter]; ok {
+			fmt.Fprintf(bw, "%s:\n", label)
+		}
+
+		arg := lineArg(line)
+		width := int(line.Inst.Code-opPUSH1) + 1
+
+		var text string
+		switch {
+		case isUndefined(line.Inst):
+			text = fmt.Sprintf(".data 0x%02x", byte(line.Inst.Code))
+		case line.Inst.Code >= opPUSH1 && line.Inst.Code <= opPUSH32 && len(arg) < width:
+			text = fmt.Sprintf(".data 0x%02x%x", byte(line.Inst.Code), arg)
+		case len(arg) > 0:
+			operand := "0x" + hex.EncodeToString(arg)
+			if dest, ok := refs[i]; ok {
+				operand = "@" + labels[dest]
+			}
+			text = strings.ToLower(line.Inst.Mnemonic) + " " + operand
+		default:
+			text = strings.ToLower(line.Inst.Mnemonic)
+		}
+
+		comment := line.BestSignature
+		if comment == "" {
+			comment = line.Comment
+		}
+
+		if comment != "" {
+			fmt.Fprintf(bw, "    %s ; %s\n", text, comment)
+		} else {
+			fmt.Fprintf(bw, "    %s\n", text)
+		}
+	}
+
+	return bw.Flush()
+}
+
+// AnnotatedFormatter writes a listing for reading, with the program counters and the instruction bytes
+// in the left columns, basic block separators and the dispatcher functions:
+//
+//	; ---- block 12 ----
+//	; function transfer(address,uint256) 0xa9059cbb
+//	000a1  5b              JUMPDEST
+//	000a2  6004            PUSH1 0x04
+type AnnotatedFormatter struct{}
+
+func (AnnotatedFormatter) Format(w io.Writer, r *Results) error {
+	functions := map[uint64][]DispatchEntry{}
+	if r.Dispatcher != nil {
+		for _, e := range r.Dispatcher.Entries {
+			functions[e.EntryPC] = append(functions[e.EntryPC], e)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, b := range BuildCFG(r).Blocks {
+		note := ""
+		if !b.Reachable {
+			note = " (unreachable)"
+		}
+		fmt.Fprintf(bw, "; ---- block %d%s ----\n", b.ID, note)
+
+		for _, e := range functions[b.Start] {
+			name := e.Signature
+			if name == "" {
+				name = "unknown"
+			}
+			fmt.Fprintf(bw, "; function %s 0x%s\n", name, e.SelectorHex())
+		}
+
+		for _, line := range b.Lines {
+			arg := lineArg(line)
+			raw := fmt.Sprintf("%02x%x", byte(line.Inst.Code), arg)
+
+			text := line.Inst.Mnemonic
+			if len(arg) > 0 {
+				text += " 0x" + hex.EncodeToString(arg)
+			}
+
+			fmt.Fprintf(bw, "%05x  %-14s  %s", line.ProgramCounter, abbreviate(raw, 14), text)
+			if line.Comment != "" {
+				fmt.Fprintf(bw, " ; %s", line.Comment)
+			}
+			bw.WriteByte('\n')
+		}
+	}
+
+	return bw.Flush()
+}
+
+// functionLabels returns the assembly labels of the dispatcher function entries,
+// named after the functions and made unique with the selectors where needed.
+func functionLabels(r *Results) map[uint64]string {
+	labels := map[uint64]string{}
+	if r.Dispatcher == nil {
+		return labels
+	}
+
+	jumpDests := map[uint64]bool{}
+	for _, line := range r.Lines {
+		if line.Inst.Code == opJUMPDEST {
+			jumpDests[line.ProgramCounter] = true
+		}
+	}
+
+	names := map[string]int{}
+	for _, e := range r.Dispatcher.Entries {
+		names[functionName(e)]++
+	}
+
+	for _, e := range r.Dispatcher.Entries {
+		// the entries of creation code are relative to the runtime code, and don't point to the lines
+		if !jumpDests[e.EntryPC] {
+			continue
+		}
+
+		if _, ok := labels[e.EntryPC]; ok {
+			continue
+		}
+
+		name := functionName(e)
+		if names[name] > 1 || name == "fn" {
+			name += "_" + e.SelectorHex()
+		}
+		labels[e.EntryPC] = name
+	}
+
+	return labels
+}
+
+// functionName returns the name of the function of the entry, usable as a label.
+func functionName(e DispatchEntry) string {
+	name := e.Signature
+	if i := strings.IndexByte(name, '('); i >= 0 {
+		name = name[:i]
+	}
+
+	if name == "" || !isLabel(name) {
+		return "fn"
+	}
+
+	return name
+}
+
+func isLabel(s string) bool {
+	for i, r := range s {
+		switch {
+		case r == '_' || r == '$':
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z':
+		case r >= '0' && r <= '9' && i > 0:
+		default:
+			return false
+		}
+	}
+
+	return true
+}
+
+// lineArg returns the immediate argument of the line.
+func lineArg(line evmops.Line) []byte {
+	return []byte(strings.Join(line.Args, ""))
+}
+
+// abbreviate shortens s to n characters, replacing the tail with "..".
+func abbreviate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+
+	return s[:n-2] + ".."
+}
@@ -0,0 +1,170 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/asm"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+func TestFormatters(t *testing.T) {
+	// PUSH1 0x80 PUSH1 0x07 JUMP INVALID __UNDEFINED(0c) JUMPDEST PUSH4 0xa9059cbb PUSH2 0xaa
+	const code = "6080600756fe0c5b63a9059cbb61aa"
+	r := disassembleOffline(t, code)
+
+	tests := []struct {
+		name string
+		f    Formatter
+		want string
+	}{
+		{
+			name: "listing",
+			f:    ListingFormatter{},
+			want: "PUSH1 80 ;  pc=0\n" +
+				"PUSH1 07 ;  pc=2\n" +
+				"JUMP ;  pc=4\n" +
+				"INVALID ;  pc=5\n" +
+				"__UNDEFINED_INSTRUCTION(0c) ;  pc=6\n" +
+				"JUMPDEST ;  pc=7\n" +
+				"PUSH4 a9059cbb ; transfer(address,uint256) pc=8\n" +
+				"PUSH2 aa ;  pc=13\n",
+		},
+		{
+			name: "geth",
+			f:    GethFormatter{},
+			want: "00000: PUSH1 0x80\n" +
+				"00002: PUSH1 0x07\n" +
+				"00004: JUMP\n" +
+				"00005: INVALID\n" +
+				"00006: opcode 0xc not defined\n" +
+				"00007: JUMPDEST\n" +
+				"00008: PUSH4 0xa9059cbb\n" +
+				"0000d: PUSH2 0xaa\n",
+		},
+		{
+			name: "asm",
+			f:    AsmFormatter{},
+			want: "    push1 0x80\n" +
+				"    push1 @loc_0007\n" +
+				"    jump\n" +
+				"    invalid\n" +
+				"    .data 0x0c\n" +
+				"loc_0007:\n" +
+				"    jumpdest\n" +
+				"    push4 0xa9059cbb ; transfer(address,uint256)\n" +
+				"    .data 0x61aa\n",
+		},
+		{
+			name: "annotated",
+			f:    AnnotatedFormatter{},
+			want: "; ---- block 0 ----\n" +
+				"00000  6080            PUSH1 0x80\n" +
+				"00002  6007            PUSH1 0x07\n" +
+				"00004  56              JUMP\n" +
+				"; ---- block 1 (unreachable) ----\n" +
+				"00005  fe              INVALID\n" +
+				"; ---- block 2 (unreachable) ----\n" +
+				"00006  0c              __UNDEFINED_INSTRUCTION(0c)\n" +
+				"; ---- block 3 ----\n" +
+				"00007  5b              JUMPDEST\n" +
+				"00008  63a9059cbb      PUSH4 0xa9059cbb ; transfer(address,uint256)\n" +
+				"0000d  61aa            PUSH2 0xaa\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := r.Format(tt.f)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got != tt.want {
+				t.Errorf("Results.Format() = \n%s\nwant\n%s", got, tt.want)
+			}
+
+			// formatting must be side-effect free
+			again, _ := r.Format(tt.f)
+			if again != got {
+				t.Errorf("Results.Format() is not repeatable, got \n%s", again)
+			}
+		})
+	}
+}
+
+func TestGethFormatter_Compatibility(t *testing.T) {
+	script, err := hex.DecodeString(uniswapRuntimeCode)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// geth v1.10.26 knows the opcodes as of Shanghai
+	d := NewDisassembler(WithSignatureResolver(OfflineSignatureResolver()), WithFork(evmops.Shanghai))
+	r, err := d.DisassembleCode(context.Background(), script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// geth fails on truncated PUSH data, so the code is cut before it
+	for _, diag := range r.Diagnostics {
+		if diag.Kind == DiagnosticTruncatedPush || diag.Kind == DiagnosticMetadata {
+			script = script[:diag.PC]
+			break
+		}
+	}
+
+	r, err = d.DisassembleCode(context.Background(), script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.Format(GethFormatter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want, err := asm.Disassemble(script)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got != strings.Join(want, "") {
+		gotLines := strings.Split(got, "\n")
+		for i, w := range want {
+			if i >= len(gotLines) || gotLines[i]+"\n" != w {
+				t.Fatalf("GethFormatter output differs from asm.Disassemble at line %d: %q, want %q", i, gotLines[i], w)
+			}
+		}
+		t.Error("GethFormatter output differs from asm.Disassemble")
+	}
+}
+
+func TestAnnotatedFormatter_Functions(t *testing.T) {
+	r := disassembleOffline(t, uniswapRuntimeCode)
+
+	got, err := r.Format(AnnotatedFormatter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !strings.Contains(got, "; function transfer(address,uint256) 0xa9059cbb\n") {
+		t.Error("AnnotatedFormatter output has no transfer function label")
+	}
+}
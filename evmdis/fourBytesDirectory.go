@@ -15,11 +15,14 @@
 package evmdis
 
 import (
+	"context"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
 	"sync"
+	"time"
 )
 
 var fourBytesCache = map[string][]string{
@@ -106,6 +109,12 @@ var fourBytesCache = map[string][]string{
 
 var fourByteCacheMu sync.RWMutex
 
+// DefaultFourByteDirectoryURL is the base URL of the public 4byte.directory API.
+const DefaultFourByteDirectoryURL = "https://www.4byte.directory"
+
+// DefaultFourByteDirectoryTimeout is the default timeout of a single 4byte.directory request.
+const DefaultFourByteDirectoryTimeout = 10 * time.Second
+
 type replyRec struct {
 	ID             uint64
 	CreatedAt      string `json:"created_at"`
@@ -121,33 +130,127 @@ type fourByteDirectoryResponse struct {
 	Results  []replyRec
 }
 
-func getSigsFromFourBytes(fourBytes []byte) []string {
+// FourBytesCacheResolver resolves selectors using the built-in static selector cache.
+// It never does any network calls.
+type FourBytesCacheResolver struct{}
+
+// NewFourBytesCacheResolver returns a resolver backed by the built-in static selector cache.
+func NewFourBytesCacheResolver() *FourBytesCacheResolver {
+	return &FourBytesCacheResolver{}
+}
+
+func (*FourBytesCacheResolver) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	if err := checkSelector(selector); err != nil {
+		return nil, err
+	}
+
 	fourByteCacheMu.RLock()
-	hexFourBytes := hex.EncodeToString(fourBytes)
-	if sigs, ok := fourBytesCache[hexFourBytes]; ok {
-		fourByteCacheMu.RUnlock()
-		return sigs
+	defer fourByteCacheMu.RUnlock()
+
+	return fourBytesCache[hex.EncodeToString(selector)], nil
+}
+
+// NewestFirst returns true, the cache keeps the 4byte.directory order.
+func (*FourBytesCacheResolver) NewestFirst() bool {
+	return true
+}
+
+// FourByteDirectoryResolver resolves selectors using the 4byte.directory HTTP API.
+// Successful lookups are memoized for the lifetime of the resolver.
+type FourByteDirectoryResolver struct {
+	// BaseURL is the base URL of the API, DefaultFourByteDirectoryURL is used if empty.
+	BaseURL string
+
+	// Timeout limits the duration of a single request, no timeout is applied if zero.
+	Timeout time.Duration
+
+	// Client is the HTTP client used for requests, http.DefaultClient is used if nil.
+	Client *http.Client
+
+	mu   sync.RWMutex
+	memo map[string][]string
+}
+
+// NewFourByteDirectoryResolver returns a resolver querying the 4byte.directory compatible API at baseURL.
+// If baseURL is empty, DefaultFourByteDirectoryURL is used.
+func NewFourByteDirectoryResolver(baseURL string, timeout time.Duration) *FourByteDirectoryResolver {
+	if baseURL == "" {
+		baseURL = DefaultFourByteDirectoryURL
+	}
+
+	return &FourByteDirectoryResolver{
+		BaseURL: baseURL,
+		Timeout: timeout,
+	}
+}
+
+// NewestFirst returns true, the API returns the newest signatures first.
+func (*FourByteDirectoryResolver) NewestFirst() bool {
+	return true
+}
+
+func (r *FourByteDirectoryResolver) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	if err := checkSelector(selector); err != nil {
+		return nil, err
+	}
+
+	hexSelector := hex.EncodeToString(selector)
+
+	r.mu.RLock()
+	if sigs, ok := r.memo[hexSelector]; ok {
+		r.mu.RUnlock()
+		return sigs, nil
+	}
+	r.mu.RUnlock()
+
+	if r.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, r.Timeout)
+		defer cancel()
+	}
+
+	baseURL := r.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultFourByteDirectoryURL
+	}
+
+	call := fmt.Sprintf("%s/api/v1/signatures/?hex_signature=0x%s", strings.TrimRight(baseURL, "/"), hexSelector)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, call, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	client := r.Client
+	if client == nil {
+		client = http.DefaultClient
 	}
 
-	fourByteCacheMu.RUnlock()
+	repl, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer repl.Body.Close()
 
-	sigs := []string{}
+	if repl.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("evmdis: 4byte directory lookup of %s: unexpected status %s", hexSelector, repl.Status)
+	}
 
-	call := fmt.Sprintf("https://www.4byte.directory/api/v1/signatures/?hex_signature=0x%08x", fourBytes)
-	repl, err := http.Get(call)
-	if err == nil {
-		var b4 fourByteDirectoryResponse
-		err = json.NewDecoder(repl.Body).Decode(&b4)
-		if err == nil {
-			for _, b4rec := range b4.Results {
-				sigs = append(sigs, b4rec.TextSignature)
-			}
-		}
+	var b4 fourByteDirectoryResponse
+	if err := json.NewDecoder(repl.Body).Decode(&b4); err != nil {
+		return nil, err
 	}
 
-	fourByteCacheMu.Lock()
-	defer fourByteCacheMu.Unlock()
-	fourBytesCache[hexFourBytes] = sigs
+	sigs := make([]string, 0, len(b4.Results))
+	for _, b4rec := range b4.Results {
+		sigs = append(sigs, b4rec.TextSignature)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.memo == nil {
+		r.memo = map[string][]string{}
+	}
+	r.memo[hexSelector] = sigs
 
-	return sigs
+	return sigs, nil
 }
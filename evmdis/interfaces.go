@@ -0,0 +1,184 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"sort"
+
+	"github.com/kirillDanshin/evmtools"
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+const supportsInterfaceSig = "supportsInterface(bytes4)"
+
+// maxInterfaceScanBlocks limits the number of basic blocks scanned from the supportsInterface entry.
+const maxInterfaceScanBlocks = 256
+
+// ClaimedInterface is an ERC-165 interface ID found in the contract code.
+type ClaimedInterface struct {
+	ID [4]byte
+
+	// Name is the name of the known interface, empty if the ID is not registered
+	Name string
+
+	// PC is the program counter of the instruction pushing the ID
+	PC uint64
+}
+
+// IDHex returns the interface ID as a hex string without 0x prefix.
+func (c ClaimedInterface) IDHex() string {
+	return hex.EncodeToString(c.ID[:])
+}
+
+// FindInterfaceIDs finds the ERC-165 interface IDs the contract claims to support.
+//
+// If the dispatcher has the supportsInterface(bytes4) function, all the 4-byte constants pushed
+// in the code reachable from its entry are reported, either as PUSH4 or left-aligned PUSH32.
+// The code is followed through the static jumps and the pushed return addresses,
+// until the entries of other functions and the dispatcher. Otherwise, only the IDs registered with
+// evmtools.RegisterInterface are looked up in the whole code.
+// The results are sorted by ID, 0x00000000 and 0xffffffff are never reported.
+func FindInterfaceIDs(r *Results) []ClaimedInterface {
+	found := map[[4]byte]ClaimedInterface{}
+	add := func(line evmops.Line, knownOnly bool) {
+		id, ok := interfaceIDConstant(line)
+		if !ok {
+			return
+		}
+
+		if _, ok := found[id]; ok {
+			return
+		}
+
+		known, ok := evmtools.LookupInterface(id[:])
+		if !ok && knownOnly {
+			return
+		}
+
+		found[id] = ClaimedInterface{ID: id, Name: known.Name, PC: line.ProgramCounter}
+	}
+
+	if blocks, ok := supportsInterfaceBlocks(r); ok {
+		for _, b := range blocks {
+			for _, line := range b.Lines {
+				add(line, false)
+			}
+		}
+	} else {
+		for _, line := range r.Lines {
+			add(line, true)
+		}
+	}
+
+	list := make([]ClaimedInterface, 0, len(found))
+	for _, c := range found {
+		list = append(list, c)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return bytes.Compare(list[i].ID[:], list[j].ID[:]) < 0
+	})
+
+	return list
+}
+
+// supportsInterfaceBlocks returns the blocks of the supportsInterface implementation.
+func supportsInterfaceBlocks(r *Results) ([]*Block, bool) {
+	if r.Dispatcher == nil {
+		return nil, false
+	}
+
+	entry, ok := r.Dispatcher.LookupSignature(supportsInterfaceSig)
+	if !ok {
+		return nil, false
+	}
+
+	g := BuildCFG(r)
+	start, ok := g.BlockAt(entry.EntryPC)
+	if !ok || !start.IsJumpDest() {
+		return nil, false
+	}
+
+	// the entries of the other functions and the dispatcher preceding the first entry bound the implementation
+	stops := map[uint64]bool{}
+	firstEntry := entry.EntryPC
+	for _, e := range r.Dispatcher.Entries {
+		if e.EntryPC != entry.EntryPC {
+			stops[e.EntryPC] = true
+		}
+		if e.EntryPC < firstEntry {
+			firstEntry = e.EntryPC
+		}
+	}
+
+	visited := map[int]bool{}
+	var blocks []*Block
+	queue := []*Block{start}
+	visit := func(b *Block) {
+		if !visited[b.ID] && !stops[b.Start] && b.Start >= firstEntry {
+			visited[b.ID] = true
+			queue = append(queue, b)
+		}
+	}
+
+	visited[start.ID] = true
+	for len(queue) > 0 && len(blocks) < maxInterfaceScanBlocks {
+		b := queue[0]
+		queue = queue[1:]
+		blocks = append(blocks, b)
+
+		for _, e := range g.Successors(b.ID) {
+			visit(g.Blocks[e.To])
+		}
+
+		// internal calls return to the pushed addresses through unresolved jumps
+		for _, line := range b.Lines {
+			if dest, ok := pushValue(line); ok {
+				if to, ok := g.jumpDestBlock(dest); ok {
+					visit(g.Blocks[to])
+				}
+			}
+		}
+	}
+
+	return blocks, true
+}
+
+// interfaceIDConstant returns the interface ID pushed by the line, either as PUSH4
+// or as PUSH32 aligned to the left, the way bytes4 values are compared.
+func interfaceIDConstant(line evmops.Line) ([4]byte, bool) {
+	var id [4]byte
+
+	if len(line.Args) != 1 {
+		return id, false
+	}
+	arg := []byte(line.Args[0])
+
+	switch {
+	case line.Inst.Code == opPUSH4 && len(arg) == 4:
+		copy(id[:], arg)
+	case line.Inst.Code == opPUSH32 && len(arg) == 32 && bytes.Count(arg[4:], []byte{0}) == 28:
+		copy(id[:], arg)
+	default:
+		return id, false
+	}
+
+	if id == [4]byte{} || id == [4]byte{0xff, 0xff, 0xff, 0xff} {
+		return id, false
+	}
+
+	return id, true
+}
@@ -0,0 +1,183 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"reflect"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmasm"
+)
+
+// supportsInterfaceSource is a dispatcher of supportsInterface(bytes4) and balanceOf(address),
+// where supportsInterface compares the argument to the ERC-165, ERC-721 and a custom ID,
+// and balanceOf pushes the ERC-20 ID, which must not be reported.
+const supportsInterfaceSource = `
+	PUSH1 0x04
+	CALLDATASIZE
+	LT
+	PUSH @fallback
+	JUMPI
+	PUSH1 0x00
+	CALLDATALOAD
+	PUSH1 0xe0
+	SHR
+	DUP1
+	PUSH4 0x01ffc9a7
+	EQ
+	PUSH @supports
+	JUMPI
+	DUP1
+	PUSH4 0x70a08231
+	EQ
+	PUSH @balance
+	JUMPI
+fallback:
+	JUMPDEST
+	PUSH1 0x00
+	DUP1
+	REVERT
+supports:
+	JUMPDEST
+	PUSH @ret
+	PUSH1 0x04
+	CALLDATALOAD
+	PUSH @impl
+	JUMP
+ret:
+	JUMPDEST
+	PUSH1 0x00
+	MSTORE
+	PUSH1 0x20
+	PUSH1 0x00
+	RETURN
+impl:
+	JUMPDEST
+	PUSH32 0x80ac58cd00000000000000000000000000000000000000000000000000000000
+	DUP2
+	EQ
+	DUP1
+	PUSH @done
+	JUMPI
+	POP
+	PUSH4 0x01ffc9a7
+	PUSH1 0xe0
+	SHL
+	DUP2
+	EQ
+	DUP1
+	PUSH @done
+	JUMPI
+	POP
+	PUSH4 0x12345678
+	PUSH1 0xe0
+	SHL
+	DUP2
+	EQ
+	PUSH4 0xffffffff
+	PUSH1 0xe0
+	SHL
+	DUP3
+	EQ
+	ISZERO
+	AND
+done:
+	JUMPDEST
+	SWAP1
+	POP
+	SWAP1
+	JUMP
+balance:
+	JUMPDEST
+	PUSH4 0x36372b07
+	PUSH1 0x00
+	MSTORE
+	PUSH1 0x20
+	PUSH1 0x00
+	RETURN
+`
+
+// knownInterfaceSource has no supportsInterface, but pushes the ERC-721 and an unknown ID.
+const knownInterfaceSource = `
+	PUSH1 0x00
+	CALLDATALOAD
+	PUSH1 0xe0
+	SHR
+	DUP1
+	PUSH4 0x70a08231
+	EQ
+	PUSH @balance
+	JUMPI
+	PUSH1 0x00
+	DUP1
+	REVERT
+balance:
+	JUMPDEST
+	PUSH4 0x80ac58cd
+	PUSH4 0x12345678
+	STOP
+`
+
+func TestFindInterfaceIDs(t *testing.T) {
+	tests := []struct {
+		name string
+		src  string
+		code string
+		want []string
+	}{
+		{
+			name: "supportsInterface implementation",
+			src:  supportsInterfaceSource,
+			want: []string{"01ffc9a7 ERC165", "12345678 ", "80ac58cd ERC721"},
+		},
+		{
+			name: "known IDs without supportsInterface",
+			src:  knownInterfaceSource,
+			want: []string{"80ac58cd ERC721"},
+		},
+		{
+			// the IDs are computed at runtime from the signature strings
+			name: "ens",
+			code: ensRuntimeCode,
+		},
+		{
+			name: "uniswap",
+			code: uniswapRuntimeCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := tt.code
+			if tt.src != "" {
+				b, err := evmasm.Assemble(tt.src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				code = hex.EncodeToString(b)
+			}
+
+			r := disassembleOffline(t, code)
+
+			var got []string
+			for _, c := range FindInterfaceIDs(r) {
+				got = append(got, c.IDHex()+" "+c.Name)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FindInterfaceIDs() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,157 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"fmt"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// DiagnosticKind is the kind of a problem found in the disassembled code.
+type DiagnosticKind uint8
+
+const (
+	// DiagnosticTruncatedPush is a PUSH at the end of the code with fewer immediate bytes than it needs.
+	// The EVM pads the missing bytes with zeros.
+	DiagnosticTruncatedPush DiagnosticKind = iota + 1
+
+	// DiagnosticUndefinedOpcode is a run of bytes that are not defined instructions,
+	// usually a data section or a part of one.
+	DiagnosticUndefinedOpcode
+
+	// DiagnosticMetadata is the compiler metadata at the end of the code, which is not disassembled.
+	DiagnosticMetadata
+
+	// DiagnosticInactiveOpcode is an instruction that is not active in the fork the code is disassembled for.
+	DiagnosticInactiveOpcode
+
+	// DiagnosticStackUnderflow is an instruction executed with fewer stack items than it takes.
+	// It is reported by AnalyzeStack.
+	DiagnosticStackUnderflow
+
+	// DiagnosticStackOverflow is an instruction growing the stack over MaxStackHeight items.
+	// It is reported by AnalyzeStack.
+	DiagnosticStackOverflow
+)
+
+func (k DiagnosticKind) String() string {
+	switch k {
+	case DiagnosticTruncatedPush:
+		return "truncated push"
+	case DiagnosticUndefinedOpcode:
+		return "undefined opcode"
+	case DiagnosticMetadata:
+		return "metadata"
+	case DiagnosticInactiveOpcode:
+		return "inactive opcode"
+	case DiagnosticStackUnderflow:
+		return "stack underflow"
+	case DiagnosticStackOverflow:
+		return "stack overflow"
+	default:
+		return "invalid"
+	}
+}
+
+// Diagnostic is a problem found in the disassembled code.
+// Diagnostics are not errors: the decodable instructions are disassembled anyway.
+type Diagnostic struct {
+	// PC is the program counter the problem starts at
+	PC uint64
+
+	Kind DiagnosticKind
+
+	// Reason is a human-readable description of the problem
+	Reason string
+}
+
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("pc=%d: %s: %s", d.PC, d.Kind, d.Reason)
+}
+
+// InstructionIterator iterates over the instructions of the bytecode.
+// Unlike the geth's asm.InstructionIterator, it never fails: a PUSH at the end of the code
+// that runs out of immediate bytes is returned with the available bytes and reported as truncated.
+type InstructionIterator struct {
+	code []byte
+	pc   uint64
+	next uint64
+
+	op        evmops.Opcode
+	arg       []byte
+	truncated bool
+}
+
+// NewInstructionIterator creates an iterator over the instructions of the code.
+func NewInstructionIterator(code []byte) *InstructionIterator {
+	return &InstructionIterator{code: code}
+}
+
+// Next advances to the next instruction and returns false if there are no more instructions.
+func (it *InstructionIterator) Next() bool {
+	if it.next >= uint64(len(it.code)) {
+		return false
+	}
+
+	it.pc = it.next
+	it.op = evmops.Opcode(it.code[it.pc])
+	it.arg = nil
+	it.truncated = false
+	it.next = it.pc + 1
+
+	if n := pushSize(it.op); n > 0 {
+		end := it.next + uint64(n)
+		if end > uint64(len(it.code)) {
+			end = uint64(len(it.code))
+			it.truncated = true
+		}
+
+		it.arg = it.code[it.next:end]
+		it.next = end
+	}
+
+	return true
+}
+
+// PC returns the program counter of the current instruction.
+func (it *InstructionIterator) PC() uint64 {
+	return it.pc
+}
+
+// Op returns the opcode of the current instruction.
+func (it *InstructionIterator) Op() evmops.Opcode {
+	return it.op
+}
+
+// Arg returns the immediate argument of the current instruction, nil if it has none.
+// The returned slice references the code and must not be modified.
+func (it *InstructionIterator) Arg() []byte {
+	return it.arg
+}
+
+// Truncated returns true if the current instruction is a PUSH that runs past the end of the code.
+func (it *InstructionIterator) Truncated() bool {
+	return it.truncated
+}
+
+// pushSize returns the number of immediate bytes of PUSH1..PUSH32, 0 for other opcodes.
+func pushSize(op evmops.Opcode) int {
+	if op < opPUSH1 || op > opPUSH32 {
+		return 0
+	}
+
+	return int(op-opPUSH1) + 1
+}
@@ -0,0 +1,208 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+func TestInstructionIterator(t *testing.T) {
+	type inst struct {
+		pc        uint64
+		op        evmops.Opcode
+		arg       string
+		truncated bool
+	}
+	tests := []struct {
+		name string
+		code []byte
+		want []inst
+	}{
+		{
+			name: "empty",
+		},
+		{
+			name: "push and stop",
+			code: []byte{0x60, 0x80, 0x61, 0x01, 0x02, 0x00},
+			want: []inst{
+				{pc: 0, op: 0x60, arg: "\x80"},
+				{pc: 2, op: 0x61, arg: "\x01\x02"},
+				{pc: 5, op: 0x00},
+			},
+		},
+		{
+			name: "truncated push",
+			code: []byte{0x5b, 0x63, 0xaa, 0xbb},
+			want: []inst{
+				{pc: 0, op: 0x5b},
+				{pc: 1, op: 0x63, arg: "\xaa\xbb", truncated: true},
+			},
+		},
+		{
+			name: "push without data",
+			code: []byte{0x7f},
+			want: []inst{
+				{pc: 0, op: 0x7f, truncated: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []inst
+			it := NewInstructionIterator(tt.code)
+			for it.Next() {
+				got = append(got, inst{pc: it.PC(), op: it.Op(), arg: string(it.Arg()), truncated: it.Truncated()})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("InstructionIterator = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisassembler_Diagnostics(t *testing.T) {
+	tests := []struct {
+		name      string
+		code      string
+		wantLines int
+		want      []Diagnostic
+	}{
+		{
+			name:      "clean",
+			code:      "6080604052600080fd",
+			wantLines: 6,
+		},
+		{
+			name:      "truncated push",
+			code:      "600160",
+			wantLines: 2,
+			want: []Diagnostic{
+				{PC: 2, Kind: DiagnosticTruncatedPush, Reason: "PUSH1 needs 1 bytes of data, 0 available"},
+			},
+		},
+		{
+			name:      "data section",
+			code:      "600056fe0c0d0e5b00",
+			wantLines: 8,
+			want: []Diagnostic{
+				{PC: 4, Kind: DiagnosticUndefinedOpcode, Reason: "3 undefined opcodes, likely data"},
+			},
+		},
+		{
+			name: "metadata",
+			code: "00" + "a165767970657283000307" + "000b",
+			// the metadata bytes are not disassembled
+			wantLines: 1,
+			want: []Diagnostic{
+				{PC: 1, Kind: DiagnosticMetadata, Reason: "13 bytes of compiler metadata are not disassembled"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := disassembleOffline(t, tt.code)
+
+			if len(got.Lines) != tt.wantLines {
+				t.Errorf("got %d lines, want %d", len(got.Lines), tt.wantLines)
+			}
+
+			if !reflect.DeepEqual(got.Diagnostics, tt.want) {
+				t.Errorf("Diagnostics = %v, want %v", got.Diagnostics, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisassembler_PUSH32Comment(t *testing.T) {
+	code := "7f" + "4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572"
+	got := disassembleOffline(t, code)
+
+	if len(got.Lines) != 1 {
+		t.Fatalf("got %d lines, want 1", len(got.Lines))
+	}
+
+	line := got.Lines[0]
+	if line.Args[0] != "Ownable: caller is not the owner" {
+		t.Errorf("PUSH32 arg = %x", line.Args[0])
+	}
+
+	if want := `"Ownable: caller is not the owner"`; line.Comment != want {
+		t.Errorf("PUSH32 comment = %s, want %s", line.Comment, want)
+	}
+}
+
+func TestDisassembler_WithFork(t *testing.T) {
+	// PUSH0 TLOAD PUSH1 0x00 SHR STOP
+	const code = "5f5c60001c00"
+
+	tests := []struct {
+		name string
+		fork evmops.Fork
+		want []Diagnostic
+	}{
+		{
+			name: "cancun",
+			fork: evmops.Cancun,
+		},
+		{
+			name: "shanghai",
+			fork: evmops.Shanghai,
+			want: []Diagnostic{
+				{PC: 1, Kind: DiagnosticInactiveOpcode, Reason: "TLOAD is not active before Cancun"},
+			},
+		},
+		{
+			name: "byzantium",
+			fork: evmops.Byzantium,
+			want: []Diagnostic{
+				{PC: 0, Kind: DiagnosticInactiveOpcode, Reason: "PUSH0 is not active before Shanghai"},
+				{PC: 1, Kind: DiagnosticInactiveOpcode, Reason: "TLOAD is not active before Cancun"},
+				{PC: 4, Kind: DiagnosticInactiveOpcode, Reason: "SHR is not active before Constantinople"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewDisassembler(WithSignatureResolver(OfflineSignatureResolver()), WithFork(tt.fork))
+			got, err := d.Disassemble(code)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(got.Lines) != 5 {
+				t.Fatalf("got %d lines, want 5", len(got.Lines))
+			}
+
+			if !reflect.DeepEqual(got.Diagnostics, tt.want) {
+				t.Errorf("Diagnostics = %v, want %v", got.Diagnostics, tt.want)
+			}
+
+			inactive := map[uint64]bool{}
+			for _, diag := range tt.want {
+				inactive[diag.PC] = true
+			}
+
+			for _, line := range got.Lines {
+				if isUndefined(line.Inst) != inactive[line.ProgramCounter] {
+					t.Errorf("instruction at pc=%d is %s", line.ProgramCounter, line.Inst.Mnemonic)
+				}
+			}
+		})
+	}
+}
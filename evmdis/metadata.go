@@ -0,0 +1,313 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrNoMetadata is returned by DecodeMetadata if the code does not end with compiler metadata.
+var ErrNoMetadata = errors.New("evmdis: no compiler metadata found")
+
+// Source hash kinds of the compiler metadata.
+const (
+	SourceHashIPFS  = "ipfs"
+	SourceHashBzzr0 = "bzzr0"
+	SourceHashBzzr1 = "bzzr1"
+)
+
+// Metadata is the CBOR-encoded compiler metadata appended to the contract code by Solidity and Vyper.
+type Metadata struct {
+	// Compiler is either "solc" or "vyper", empty if the compiler is not specified,
+	// e.g. for Solidity versions before 0.5.9.
+	Compiler string
+
+	// Version is the compiler version, e.g. "0.8.10".
+	// Solidity nightly builds are reported with their full version string.
+	Version string
+
+	// SourceHashKind is one of SourceHashIPFS, SourceHashBzzr0 and SourceHashBzzr1,
+	// empty if there is no source metadata hash.
+	SourceHashKind string
+
+	// SourceHash is the hash of the Solidity metadata JSON, it includes
+	// the multihash prefix for IPFS.
+	SourceHash []byte
+
+	// Experimental is true if the contract uses experimental compiler features.
+	Experimental bool
+
+	// Offset is the offset of the metadata in the code.
+	Offset int
+
+	// Raw is the metadata as it is stored in the code, including the 2-byte length suffix.
+	Raw []byte
+}
+
+// DecodeMetadata locates and decodes the compiler metadata at the end of the runtime code.
+//
+// Solidity appends a CBOR map with "ipfs", "bzzr0" or "bzzr1" source hash, "solc" version
+// and "experimental" flag; Vyper appends either a map with "vyper" version or, since 0.3.10,
+// an array holding code section sizes and that map. Both are followed by the 2-byte big endian
+// length of the CBOR data.
+func DecodeMetadata(code []byte) (*Metadata, error) {
+	if len(code) < 2 {
+		return nil, ErrNoMetadata
+	}
+
+	n := int(code[len(code)-2])<<8 | int(code[len(code)-1])
+	start := len(code) - 2 - n
+	if n == 0 || start < 0 {
+		return nil, ErrNoMetadata
+	}
+
+	d := &cborDecoder{data: code[start : len(code)-2]}
+	v, err := d.decode(0)
+	if err != nil || d.pos != len(d.data) {
+		return nil, ErrNoMetadata
+	}
+
+	md := &Metadata{
+		Offset: start,
+		Raw:    code[start:],
+	}
+
+	switch v := v.(type) {
+	case map[string]interface{}:
+		if err := md.fill(v); err != nil {
+			return nil, err
+		}
+	case []interface{}:
+		// Vyper 0.3.10+: [..., {"vyper": [major, minor, patch]}]
+		if len(v) == 0 {
+			return nil, ErrNoMetadata
+		}
+
+		m, ok := v[len(v)-1].(map[string]interface{})
+		if !ok {
+			return nil, ErrNoMetadata
+		}
+
+		if err := md.fill(m); err != nil {
+			return nil, err
+		}
+
+		if md.Compiler != "vyper" {
+			return nil, ErrNoMetadata
+		}
+	default:
+		return nil, ErrNoMetadata
+	}
+
+	return md, nil
+}
+
+func (md *Metadata) fill(m map[string]interface{}) error {
+	known := false
+	for key, v := range m {
+		switch key {
+		case SourceHashIPFS, SourceHashBzzr0, SourceHashBzzr1:
+			hash, ok := v.([]byte)
+			if !ok {
+				return fmt.Errorf("evmdis: invalid %s metadata hash", key)
+			}
+			md.SourceHashKind = key
+			md.SourceHash = hash
+			known = true
+
+		case "solc", "vyper":
+			version, err := metadataVersion(v)
+			if err != nil {
+				return err
+			}
+			md.Compiler = key
+			md.Version = version
+			known = true
+
+		case "experimental":
+			experimental, ok := v.(bool)
+			if !ok {
+				return errors.New("evmdis: invalid experimental metadata flag")
+			}
+			md.Experimental = experimental
+		}
+	}
+
+	if !known {
+		return ErrNoMetadata
+	}
+
+	return nil
+}
+
+// metadataVersion formats the compiler version, stored either as bytes or integers per component,
+// or as a string for Solidity nightly builds.
+func metadataVersion(v interface{}) (string, error) {
+	switch v := v.(type) {
+	case []byte:
+		parts := make([]string, len(v))
+		for i, b := range v {
+			parts[i] = strconv.Itoa(int(b))
+		}
+		return strings.Join(parts, "."), nil
+
+	case []interface{}:
+		parts := make([]string, len(v))
+		for i, x := range v {
+			n, ok := x.(uint64)
+			if !ok {
+				return "", errors.New("evmdis: invalid compiler version in metadata")
+			}
+			parts[i] = strconv.FormatUint(n, 10)
+		}
+		return strings.Join(parts, "."), nil
+
+	case string:
+		return v, nil
+	}
+
+	return "", errors.New("evmdis: invalid compiler version in metadata")
+}
+
+// cborDecoder is a minimal CBOR decoder, sufficient for compiler metadata:
+// unsigned integers, byte and text strings, arrays, maps with text keys and simple values,
+// all of definite length.
+type cborDecoder struct {
+	data []byte
+	pos  int
+}
+
+const cborMaxDepth = 8
+
+var errCBOR = errors.New("evmdis: unsupported or invalid CBOR data")
+
+func (d *cborDecoder) decode(depth int) (interface{}, error) {
+	if depth > cborMaxDepth || d.pos >= len(d.data) {
+		return nil, errCBOR
+	}
+
+	head := d.data[d.pos]
+	d.pos++
+
+	major, info := head>>5, head&0x1f
+
+	if major == 7 {
+		switch info {
+		case 20:
+			return false, nil
+		case 21:
+			return true, nil
+		case 22:
+			return nil, nil
+		}
+		return nil, errCBOR
+	}
+
+	arg, err := d.argument(info)
+	if err != nil {
+		return nil, err
+	}
+
+	switch major {
+	case 0:
+		return arg, nil
+
+	case 2, 3:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBOR
+		}
+		b := d.data[d.pos : d.pos+int(arg)]
+		d.pos += int(arg)
+		if major == 3 {
+			return string(b), nil
+		}
+		return b, nil
+
+	case 4:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBOR
+		}
+		items := make([]interface{}, 0, arg)
+		for i := uint64(0); i < arg; i++ {
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, v)
+		}
+		return items, nil
+
+	case 5:
+		if arg > uint64(len(d.data)-d.pos) {
+			return nil, errCBOR
+		}
+		m := make(map[string]interface{}, arg)
+		for i := uint64(0); i < arg; i++ {
+			k, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			key, ok := k.(string)
+			if !ok {
+				return nil, errCBOR
+			}
+			v, err := d.decode(depth + 1)
+			if err != nil {
+				return nil, err
+			}
+			m[key] = v
+		}
+		return m, nil
+	}
+
+	return nil, errCBOR
+}
+
+// argument reads the argument of a data item head with the given additional information.
+func (d *cborDecoder) argument(info byte) (uint64, error) {
+	if info < 24 {
+		return uint64(info), nil
+	}
+
+	var n int
+	switch info {
+	case 24:
+		n = 1
+	case 25:
+		n = 2
+	case 26:
+		n = 4
+	case 27:
+		n = 8
+	default:
+		// indefinite lengths and reserved values
+		return 0, errCBOR
+	}
+
+	if d.pos+n > len(d.data) {
+		return 0, errCBOR
+	}
+
+	var v uint64
+	for _, b := range d.data[d.pos : d.pos+n] {
+		v = v<<8 | uint64(b)
+	}
+	d.pos += n
+
+	return v, nil
+}
@@ -0,0 +1,133 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestDecodeMetadata(t *testing.T) {
+	tests := []struct {
+		name           string
+		code           string
+		wantCompiler   string
+		wantVersion    string
+		wantHashKind   string
+		wantHash       string
+		wantExperiment bool
+		wantOffset     int
+		wantErr        error
+	}{
+		{
+			name:         "solc 0.8 ipfs",
+			code:         "00" + "a2646970667358221220c012fb8a45d900bfe25cf6c18046e733f934052ff024add1f6db05fec869b97864736f6c634300080a0033",
+			wantCompiler: "solc",
+			wantVersion:  "0.8.10",
+			wantHashKind: SourceHashIPFS,
+			wantHash:     "1220c012fb8a45d900bfe25cf6c18046e733f934052ff024add1f6db05fec869b978",
+			wantOffset:   1,
+		},
+		{
+			name:         "solc 0.4 bzzr0",
+			code:         "fe" + "a165627a7a72305820645ee12d73db47fd78ba77fa1f824c3c8f9184061b3b10386beb4dc9236abb280029",
+			wantHashKind: SourceHashBzzr0,
+			wantHash:     "645ee12d73db47fd78ba77fa1f824c3c8f9184061b3b10386beb4dc9236abb28",
+			wantOffset:   1,
+		},
+		{
+			name: "solc experimental bzzr1",
+			code: "a3" +
+				"65627a7a7231" + "5820" + "0000000000000000000000000000000000000000000000000000000000000001" +
+				"6c6578706572696d656e74616cf5" +
+				"64736f6c6343000510" + "0040",
+			wantCompiler:   "solc",
+			wantVersion:    "0.5.16",
+			wantHashKind:   SourceHashBzzr1,
+			wantHash:       "0000000000000000000000000000000000000000000000000000000000000001",
+			wantExperiment: true,
+		},
+		{
+			name:         "vyper 0.3.7",
+			code:         "5b00" + "a165767970657283000307" + "000b",
+			wantCompiler: "vyper",
+			wantVersion:  "0.3.7",
+			wantOffset:   2,
+		},
+		{
+			name: "vyper 0.3.10",
+			// [0x0123, [], 10, {"vyper": [0, 3, 10]}]
+			code:         "00" + "84190123800a" + "a1657679706572830003" + "0a" + "0011",
+			wantCompiler: "vyper",
+			wantVersion:  "0.3.10",
+			wantOffset:   1,
+		},
+		{
+			name:    "no metadata",
+			code:    "6080604052600080fd",
+			wantErr: ErrNoMetadata,
+		},
+		{
+			name:    "length out of bounds",
+			code:    "a1ffff",
+			wantErr: ErrNoMetadata,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := hex.DecodeString(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := DecodeMetadata(code)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("DecodeMetadata() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+
+			if got.Compiler != tt.wantCompiler || got.Version != tt.wantVersion || got.Experimental != tt.wantExperiment {
+				t.Errorf("DecodeMetadata() = %s %s experimental=%v", got.Compiler, got.Version, got.Experimental)
+			}
+
+			if got.SourceHashKind != tt.wantHashKind || hex.EncodeToString(got.SourceHash) != tt.wantHash {
+				t.Errorf("DecodeMetadata() hash = %s %x", got.SourceHashKind, got.SourceHash)
+			}
+
+			if got.Offset != tt.wantOffset || len(got.Raw) != len(code)-tt.wantOffset {
+				t.Errorf("DecodeMetadata() offset = %d, raw length %d", got.Offset, len(got.Raw))
+			}
+		})
+	}
+}
+
+func TestDisassembler_StripsMetadata(t *testing.T) {
+	r := disassembleOffline(t, ensRuntimeCode)
+	if r.Metadata == nil {
+		t.Fatal("expected ENS metadata to be found")
+	}
+
+	last := r.Lines[len(r.Lines)-1]
+	if last.ProgramCounter >= uint64(r.Metadata.Offset) {
+		t.Errorf("metadata was disassembled: last line at pc=%d, metadata at %d", last.ProgramCounter, r.Metadata.Offset)
+	}
+
+	if r.CompiledLen != len(ensRuntimeCode)/2 {
+		t.Errorf("CompiledLen = %d, want %d", r.CompiledLen, len(ensRuntimeCode)/2)
+	}
+}
@@ -0,0 +1,331 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/hex"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+const (
+	opPUSH20       evmops.Opcode = 0x73
+	opDELEGATECALL evmops.Opcode = 0xf4
+)
+
+// Well-known storage slots of proxy contracts.
+var (
+	// SlotEIP1967Implementation is keccak256("eip1967.proxy.implementation") - 1
+	SlotEIP1967Implementation = mustDecodeSlot("360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc")
+
+	// SlotEIP1967Beacon is keccak256("eip1967.proxy.beacon") - 1
+	SlotEIP1967Beacon = mustDecodeSlot("a3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50")
+
+	// SlotEIP1967Admin is keccak256("eip1967.proxy.admin") - 1
+	SlotEIP1967Admin = mustDecodeSlot("b53127684a568b3173ae13b9f8a6016e243e63b6e8ee1178d6a717850b5d6103")
+
+	// SlotEIP1822Proxiable is keccak256("PROXIABLE"), the implementation slot of EIP-1822 proxies
+	SlotEIP1822Proxiable = mustDecodeSlot("c5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7")
+
+	// SlotZeppelinImplementation is keccak256("org.zeppelinos.proxy.implementation"),
+	// the implementation slot of ZeppelinOS and early OpenZeppelin upgradeability proxies
+	SlotZeppelinImplementation = mustDecodeSlot("7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3")
+
+	// SlotDiamondStorage is keccak256("diamond.standard.diamond.storage"),
+	// the storage of the EIP-2535 reference implementation
+	SlotDiamondStorage = mustDecodeSlot("c8fcad8db84d3cc18b4c41d551ea0ee66dd599cde068d998e57d5e09332c131c")
+)
+
+// Functions identifying proxies and their implementations.
+const (
+	proxiableUUIDSig = "proxiableUUID()"
+	facetAddressSig  = "facetAddress(bytes4)"
+	diamondCutSig    = "diamondCut((address,uint8,bytes4[])[],address,bytes)"
+)
+
+// ProxyKind is the kind of a proxy contract.
+type ProxyKind uint8
+
+const (
+	// ProxyMinimal is the EIP-1167 minimal proxy, or its ERC-7511 variant using PUSH0,
+	// with the implementation address embedded in the code.
+	ProxyMinimal ProxyKind = iota + 1
+
+	// ProxyTransparent is the EIP-1967 proxy that also keeps the admin address,
+	// e.g. OpenZeppelin TransparentUpgradeableProxy.
+	ProxyTransparent
+
+	// ProxyUUPS is the EIP-1967 proxy without an admin, upgraded by the implementation itself,
+	// or the original EIP-1822 proxy.
+	ProxyUUPS
+
+	// ProxyBeacon is the EIP-1967 beacon proxy, that asks the beacon contract for the implementation.
+	ProxyBeacon
+
+	// ProxyDiamond is the EIP-2535 diamond, that delegates every function to its own facet.
+	ProxyDiamond
+
+	// ProxyZeppelin is the ZeppelinOS upgradeability proxy, predating EIP-1967.
+	ProxyZeppelin
+)
+
+func (k ProxyKind) String() string {
+	switch k {
+	case ProxyMinimal:
+		return "EIP-1167 minimal proxy"
+	case ProxyTransparent:
+		return "EIP-1967 transparent proxy"
+	case ProxyUUPS:
+		return "UUPS proxy"
+	case ProxyBeacon:
+		return "EIP-1967 beacon proxy"
+	case ProxyDiamond:
+		return "EIP-2535 diamond"
+	case ProxyZeppelin:
+		return "ZeppelinOS proxy"
+	default:
+		return "invalid"
+	}
+}
+
+// ImplementationLocation is where a proxy keeps the address of its implementation.
+type ImplementationLocation uint8
+
+const (
+	// ImplementationInCode means the address is embedded in the proxy code, see Proxy.Implementation.
+	ImplementationInCode ImplementationLocation = iota + 1
+
+	// ImplementationInSlot means the address is stored in the proxy storage slot Proxy.Slot.
+	ImplementationInSlot
+
+	// ImplementationInBeacon means the beacon address is stored in the proxy storage slot Proxy.Slot,
+	// and the implementation address is returned by implementation() of the beacon.
+	ImplementationInBeacon
+
+	// ImplementationPerSelector means there is an implementation per function selector,
+	// kept in the selector to facet mapping of the diamond storage at Proxy.Slot.
+	ImplementationPerSelector
+)
+
+func (l ImplementationLocation) String() string {
+	switch l {
+	case ImplementationInCode:
+		return "code"
+	case ImplementationInSlot:
+		return "storage slot"
+	case ImplementationInBeacon:
+		return "beacon"
+	case ImplementationPerSelector:
+		return "facet per selector"
+	default:
+		return "invalid"
+	}
+}
+
+// Proxy describes the proxy pattern detected in the contract code.
+type Proxy struct {
+	Kind     ProxyKind
+	Location ImplementationLocation
+
+	// Implementation is the implementation address embedded in the code of minimal proxies.
+	// Addresses pushed with less than 20 bytes are padded with zeros on the left.
+	Implementation [20]byte
+
+	// Slot is the storage slot holding the implementation or the beacon address,
+	// or the diamond storage; it is zero if unknown.
+	Slot [32]byte
+
+	// PC is the program counter of the instruction pushing the implementation address or the slot,
+	// zero if there is no such instruction.
+	PC uint64
+}
+
+// ImplementationHex returns the embedded implementation address as a 0x-prefixed hex string,
+// or an empty string if the implementation is not in the code.
+func (p *Proxy) ImplementationHex() string {
+	if p.Location != ImplementationInCode {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString(p.Implementation[:])
+}
+
+// SlotHex returns the storage slot as a 0x-prefixed hex string, or an empty string if it is unknown.
+func (p *Proxy) SlotHex() string {
+	if p.Slot == [32]byte{} {
+		return ""
+	}
+
+	return "0x" + hex.EncodeToString(p.Slot[:])
+}
+
+// minimalProxyForms are the minimal proxy codes around the implementation address push.
+// The jump destination of the revert branch depends on the width of the push,
+// so it is checked separately.
+var minimalProxyForms = []struct {
+	prefix, suffix []byte
+	// base is the JUMPDEST offset minus the address width
+	base int
+}{
+	{
+		// EIP-1167
+		prefix: []byte{0x36, 0x3d, 0x3d, 0x37, 0x3d, 0x3d, 0x3d, 0x36, 0x3d},
+		suffix: []byte{0x5a, 0xf4, 0x3d, 0x82, 0x80, 0x3e, 0x90, 0x3d, 0x91, 0x60},
+		base:   23,
+	},
+	{
+		// ERC-7511
+		prefix: []byte{0x36, 0x5f, 0x5f, 0x37, 0x5f, 0x5f, 0x36, 0x5f},
+		suffix: []byte{0x5a, 0xf4, 0x3d, 0x5f, 0x5f, 0x3e, 0x5f, 0x3d, 0x91, 0x60},
+		base:   22,
+	},
+}
+
+// DetectProxy recognises the proxy patterns in the disassembled runtime code,
+// and returns nil if the contract doesn't look like a proxy.
+//
+// Minimal proxies are matched byte by byte, the implementation address may be pushed
+// with less than 20 bytes, and any data may follow the code. Other proxies are recognised by
+// the well-known storage slots pushed with PUSH32 along with a DELEGATECALL in the code:
+// the EIP-1967 beacon, implementation and admin slots, the EIP-1822 and ZeppelinOS implementation slots,
+// and the EIP-2535 reference diamond storage. A diamond is also recognised by the facetAddress(bytes4)
+// or diamondCut functions in the dispatcher.
+//
+// Contracts with proxiableUUID() are UUPS implementations rather than proxies,
+// so DetectProxy returns nil for them.
+func DetectProxy(r *Results) *Proxy {
+	if p := detectMinimalProxy(r.Lines); p != nil {
+		return p
+	}
+
+	if r.hasFunction(proxiableUUIDSig) {
+		return nil
+	}
+
+	delegates := false
+	slots := map[[32]byte]uint64{}
+	for _, line := range r.Lines {
+		if line.Inst.Code == opDELEGATECALL {
+			delegates = true
+		}
+
+		if line.Inst.Code != opPUSH32 || len(line.Args) != 1 || len(line.Args[0]) != 32 {
+			continue
+		}
+
+		var slot [32]byte
+		copy(slot[:], line.Args[0])
+		if _, ok := slots[slot]; !ok {
+			slots[slot] = line.ProgramCounter
+		}
+	}
+
+	if !delegates {
+		return nil
+	}
+
+	slotProxy := func(kind ProxyKind, loc ImplementationLocation, slot [32]byte) *Proxy {
+		return &Proxy{Kind: kind, Location: loc, Slot: slot, PC: slots[slot]}
+	}
+
+	has := func(slot [32]byte) bool {
+		_, ok := slots[slot]
+		return ok
+	}
+
+	switch {
+	case has(SlotDiamondStorage):
+		return slotProxy(ProxyDiamond, ImplementationPerSelector, SlotDiamondStorage)
+	case r.hasFunction(facetAddressSig) || r.hasFunction(diamondCutSig):
+		return &Proxy{Kind: ProxyDiamond, Location: ImplementationPerSelector}
+	case has(SlotEIP1967Beacon):
+		return slotProxy(ProxyBeacon, ImplementationInBeacon, SlotEIP1967Beacon)
+	case has(SlotEIP1967Implementation) && has(SlotEIP1967Admin):
+		return slotProxy(ProxyTransparent, ImplementationInSlot, SlotEIP1967Implementation)
+	case has(SlotEIP1967Implementation):
+		return slotProxy(ProxyUUPS, ImplementationInSlot, SlotEIP1967Implementation)
+	case has(SlotEIP1822Proxiable):
+		return slotProxy(ProxyUUPS, ImplementationInSlot, SlotEIP1822Proxiable)
+	case has(SlotZeppelinImplementation):
+		return slotProxy(ProxyZeppelin, ImplementationInSlot, SlotZeppelinImplementation)
+	}
+
+	return nil
+}
+
+// detectMinimalProxy matches the code of the lines against minimalProxyForms.
+func detectMinimalProxy(lines []evmops.Line) *Proxy {
+	var code []byte
+	for _, line := range lines {
+		// the longest form is well within the first 64 bytes
+		if len(code) >= 64 {
+			break
+		}
+
+		code = append(code, byte(line.Inst.Code))
+		for _, arg := range line.Args {
+			code = append(code, arg...)
+		}
+	}
+
+	for _, form := range minimalProxyForms {
+		if !bytes.HasPrefix(code, form.prefix) || len(code) <= len(form.prefix) {
+			continue
+		}
+
+		pushPC := len(form.prefix)
+		op := evmops.Opcode(code[pushPC])
+		if op < opPUSH1 || op > opPUSH20 {
+			continue
+		}
+
+		n := int(op-opPUSH1) + 1
+		rest := code[pushPC+1:]
+		if len(rest) < n+len(form.suffix)+4 {
+			continue
+		}
+
+		addr, rest := rest[:n], rest[n:]
+		if !bytes.HasPrefix(rest, form.suffix) {
+			continue
+		}
+
+		rest = rest[len(form.suffix):]
+		if int(rest[0]) != form.base+n || !bytes.HasPrefix(rest[1:], []byte{0x57, 0xfd, 0x5b, 0xf3}) {
+			continue
+		}
+
+		p := &Proxy{
+			Kind:     ProxyMinimal,
+			Location: ImplementationInCode,
+			PC:       uint64(pushPC),
+		}
+		copy(p.Implementation[20-n:], addr)
+
+		return p
+	}
+
+	return nil
+}
+
+func mustDecodeSlot(s string) [32]byte {
+	var slot [32]byte
+	if n, err := hex.Decode(slot[:], []byte(s)); err != nil || n != len(slot) {
+		panic("evmdis: invalid slot " + s)
+	}
+
+	return slot
+}
@@ -0,0 +1,169 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"fmt"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmasm"
+)
+
+// delegatingSource is a fallback delegating the call to the address loaded from the slot,
+// with the extra PUSH32 constants and dispatcher prepended by the test cases.
+const delegatingSource = `
+	PUSH32 0x%x
+	SLOAD
+	CALLDATASIZE
+	PUSH1 0x00
+	DUP1
+	CALLDATACOPY
+	PUSH1 0x00
+	DUP1
+	CALLDATASIZE
+	PUSH1 0x00
+	DUP5
+	GAS
+	DELEGATECALL
+	RETURNDATASIZE
+	PUSH1 0x00
+	DUP1
+	RETURNDATACOPY
+	RETURNDATASIZE
+	PUSH1 0x00
+	RETURN
+`
+
+func TestDetectProxy(t *testing.T) {
+	delegating := func(prefix string, slot [32]byte) string {
+		return prefix + fmt.Sprintf(delegatingSource, slot)
+	}
+
+	facetAddressDispatcher := `
+		PUSH1 0x00
+		CALLDATALOAD
+		PUSH1 0xe0
+		SHR
+		DUP1
+		PUSH4 0xcdffacc6
+		EQ
+		PUSH @facet
+		JUMPI
+		PUSH @fallback
+		JUMP
+	facet:
+		JUMPDEST
+		STOP
+	fallback:
+		JUMPDEST
+	`
+
+	tests := []struct {
+		name string
+		code string
+		src  string
+		want string
+	}{
+		{
+			name: "eip-1167",
+			code: "363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602b57fd5bf3",
+			want: "EIP-1167 minimal proxy code 0xbebebebebebebebebebebebebebebebebebebebe  9",
+		},
+		{
+			name: "eip-1167 vanity address with immutable args",
+			code: "363d3d373d3d3d363d6e0102030405060708090a0b0c0d0e0f5af43d82803e903d91602657fd5bf3" + "deadbeef",
+			want: "EIP-1167 minimal proxy code 0x00000000000102030405060708090a0b0c0d0e0f  9",
+		},
+		{
+			name: "erc-7511",
+			code: "365f5f375f5f365f73bebebebebebebebebebebebebebebebebebebebe5af43d5f5f3e5f3d91602a57fd5bf3",
+			want: "EIP-1167 minimal proxy code 0xbebebebebebebebebebebebebebebebebebebebe  8",
+		},
+		{
+			name: "eip-1167 with wrong jump destination",
+			code: "363d3d373d3d3d363d73bebebebebebebebebebebebebebebebebebebebe5af43d82803e903d91602a57fd5bf3",
+		},
+		{
+			name: "uups",
+			src:  delegating("", SlotEIP1967Implementation),
+			want: "UUPS proxy storage slot  0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc 0",
+		},
+		{
+			name: "transparent",
+			src:  delegating(fmt.Sprintf("PUSH32 0x%x\nPOP\n", SlotEIP1967Admin), SlotEIP1967Implementation),
+			want: "EIP-1967 transparent proxy storage slot  0x360894a13ba1a3210667c828492db98dca3e2076cc3735a920a3ca505d382bbc 34",
+		},
+		{
+			name: "beacon",
+			src:  delegating("", SlotEIP1967Beacon),
+			want: "EIP-1967 beacon proxy beacon  0xa3f0ad74e5423aebfd80d3ef4346578335a9a72aeaee59ff6cb3582b35133d50 0",
+		},
+		{
+			name: "eip-1822",
+			src:  delegating("", SlotEIP1822Proxiable),
+			want: "UUPS proxy storage slot  0xc5f16f0fcc639fa48a6947836d9850f504798523bf8c9a3a87d5876cf622bcf7 0",
+		},
+		{
+			name: "zeppelin",
+			src:  delegating("", SlotZeppelinImplementation),
+			want: "ZeppelinOS proxy storage slot  0x7050c9e0f4ca769c69bd3a8ef740bc37934f8e2c036e5a723fd8ee048ed3f8c3 0",
+		},
+		{
+			name: "diamond storage",
+			src:  delegating("", SlotDiamondStorage),
+			want: "EIP-2535 diamond facet per selector  0xc8fcad8db84d3cc18b4c41d551ea0ee66dd599cde068d998e57d5e09332c131c 0",
+		},
+		{
+			name: "diamond loupe",
+			src:  delegating(facetAddressDispatcher, [32]byte{1}),
+			want: "EIP-2535 diamond facet per selector   0",
+		},
+		{
+			name: "slot without delegatecall",
+			src:  fmt.Sprintf("PUSH32 0x%x\nSLOAD\nSTOP", SlotEIP1967Implementation),
+		},
+		{
+			name: "uniswap",
+			code: uniswapRuntimeCode,
+		},
+		{
+			name: "ens",
+			code: ensRuntimeCode,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := tt.code
+			if tt.src != "" {
+				b, err := evmasm.Assemble(tt.src)
+				if err != nil {
+					t.Fatal(err)
+				}
+				code = hex.EncodeToString(b)
+			}
+
+			r := disassembleOffline(t, code)
+
+			var got string
+			if p := DetectProxy(r); p != nil {
+				got = fmt.Sprintf("%s %s %s %s %d", p.Kind, p.Location, p.ImplementationHex(), p.SlotHex(), p.PC)
+			}
+			if got != tt.want {
+				t.Errorf("DetectProxy() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
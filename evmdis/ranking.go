@@ -0,0 +1,100 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"sort"
+
+	"github.com/kirillDanshin/evmtools/evmfuncs"
+)
+
+// ScoredSignature is a candidate signature of a selector with its ranking score.
+type ScoredSignature = evmfuncs.ScoredSignature
+
+// Calldata cross-check weights, in addition to the evmfuncs signature ranking weights.
+const (
+	scoreCalldataMatch   = 50
+	scoreCalldataNoMatch = -50
+)
+
+// RankSignatures scores candidate signatures of the selector and returns them sorted best first.
+//
+// The candidates are scored by evmfuncs.ScoreSignature, as evmfuncs.CalldataDecoder does, and are expected to be in 4byte.directory order
+// (newest first), so the older entries are preferred among otherwise equal ones. If calldata samples
+// starting with the selector are given, candidates that fail to decode them are demoted as well.
+func RankSignatures(selector []byte, candidates []string, calldata ...[]byte) []ScoredSignature {
+	return rankSignatures(selector, candidates, true, calldata)
+}
+
+// rankSignatures is RankSignatures of the candidates in arbitrary order if newestFirst is false.
+func rankSignatures(selector []byte, candidates []string, newestFirst bool, calldata [][]byte) []ScoredSignature {
+	scored := make([]ScoredSignature, 0, len(candidates))
+	seen := map[string]struct{}{}
+
+	for i, sig := range candidates {
+		if _, ok := seen[sig]; ok {
+			continue
+		}
+		seen[sig] = struct{}{}
+
+		age := -1
+		if newestFirst {
+			age = i
+		}
+
+		score := evmfuncs.ScoreSignature(selector, sig, age)
+		score += calldataScore(sig, selector, calldata)
+
+		scored = append(scored, ScoredSignature{Signature: sig, Score: score})
+	}
+
+	sort.SliceStable(scored, func(i, j int) bool {
+		return scored[i].Score > scored[j].Score
+	})
+
+	return scored
+}
+
+func calldataScore(sig string, selector []byte, calldata [][]byte) int {
+	score := 0
+	for _, data := range calldata {
+		if !bytes.HasPrefix(data, selector) {
+			continue
+		}
+
+		fsig, err := evmfuncs.NewFuncSignatureFromString(sig)
+		if err != nil {
+			return scoreCalldataNoMatch
+		}
+
+		if _, err := fsig.UnpackInput(data); err != nil {
+			return scoreCalldataNoMatch
+		}
+
+		score = scoreCalldataMatch
+	}
+
+	return score
+}
+
+// BestSignature returns the best ranked signature, or an empty string if there are no candidates.
+func BestSignature(scored []ScoredSignature) string {
+	if len(scored) == 0 {
+		return ""
+	}
+
+	return scored[0].Signature
+}
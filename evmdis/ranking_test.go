@@ -0,0 +1,196 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"encoding/hex"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools"
+	"github.com/kirillDanshin/evmtools/evmfuncs"
+)
+
+func TestRankSignatures(t *testing.T) {
+	mustDecode := func(s string) []byte {
+		b, err := hex.DecodeString(s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+
+	tests := []struct {
+		name       string
+		selector   string
+		candidates []string
+		calldata   []byte
+		want       string
+	}{
+		{
+			name:       "well-known wins over spam",
+			selector:   "a9059cbb",
+			candidates: fourBytesCache["a9059cbb"],
+			want:       "transfer(address,uint256)",
+		},
+		{
+			name:       "spam demoted",
+			selector:   "d505accf",
+			candidates: fourBytesCache["d505accf"],
+			want:       "permit(address,address,uint256,uint256,uint8,bytes32,bytes32)",
+		},
+		{
+			name:       "snake case demoted",
+			selector:   "18160ddd",
+			candidates: fourBytesCache["18160ddd"],
+			want:       "totalSupply()",
+		},
+		{
+			name:       "oldest preferred",
+			selector:   "ffffffff",
+			candidates: []string{"LOCK8605463013()", "test266151307()"},
+			want:       "test266151307()",
+		},
+		{
+			name:       "selector mismatch demoted",
+			selector:   "0e136b19",
+			candidates: []string{"deprecated()", "notDeprecated()"},
+			want:       "deprecated()",
+		},
+		{
+			name:       "calldata cross-check",
+			selector:   "2e1a7d4d",
+			candidates: []string{"withdraw(uint256)", "withdraw(uint256[])"},
+			calldata:   mustDecode("2e1a7d4d0000000000000000000000000000000000000000000000000de0b6b3a7640000"),
+			want:       "withdraw(uint256)",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var samples [][]byte
+			if tt.calldata != nil {
+				samples = append(samples, tt.calldata)
+			}
+
+			got := RankSignatures(mustDecode(tt.selector), tt.candidates, samples...)
+			if best := BestSignature(got); best != tt.want {
+				t.Errorf("RankSignatures() best = %q, want %q, ranking %v", best, tt.want, got)
+			}
+		})
+	}
+}
+
+func TestRankSignatures_SharedScore(t *testing.T) {
+	wrapper := "forwardCall(address,bytes)"
+	if err := evmfuncs.RegisterWrapper(evmfuncs.Wrapper{Signature: "forwardCall(address to, bytes data)", Data: "data", To: "to"}); err != nil {
+		t.Fatal(err)
+	}
+
+	candidates := []string{wrapper, "transfer(address,uint256)", "free_ether_withdrawal()"}
+	for _, sig := range candidates {
+		selector := evmtools.MethodID(sig)
+
+		got := RankSignatures(selector, []string{sig})
+		if want := evmfuncs.ScoreSignature(selector, sig, 0); len(got) != 1 || got[0].Score != want {
+			t.Errorf("RankSignatures(%s) = %v, want score %d", sig, got, want)
+		}
+	}
+
+	// registered wrappers are preferred only by evmfuncs.CalldataDecoder
+	if got := RankSignatures(evmtools.MethodID(wrapper), []string{wrapper}); got[0].Score != 0 {
+		t.Errorf("RankSignatures(%s) score = %d, want 0", wrapper, got[0].Score)
+	}
+}
+
+func TestCalldataScore(t *testing.T) {
+	data, _ := hex.DecodeString("2e1a7d4d0000000000000000000000000000000000000000000000000de0b6b3a7640000")
+	sel := data[:4]
+
+	if got := calldataScore("withdraw(uint256)", sel, [][]byte{data}); got != scoreCalldataMatch {
+		t.Errorf("calldataScore(withdraw(uint256)) = %d, want %d", got, scoreCalldataMatch)
+	}
+
+	if got := calldataScore("withdraw(uint256[])", sel, [][]byte{data}); got != scoreCalldataNoMatch {
+		t.Errorf("calldataScore(withdraw(uint256[])) = %d, want %d", got, scoreCalldataNoMatch)
+	}
+
+	if got := calldataScore("withdraw(uint256)", []byte{1, 2, 3, 4}, [][]byte{data}); got != 0 {
+		t.Errorf("calldataScore() of unrelated calldata = %d, want 0", got)
+	}
+}
+
+func TestDisassembler_FoundSignaturesRanked(t *testing.T) {
+	// PUSH4 0xa9059cbb STOP
+	got, err := NewDisassembler(WithSignatureResolver(NewFourBytesCacheResolver())).Disassemble("63a9059cbb00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := map[string]struct{}{"transfer(address,uint256)": {}}
+	if !reflect.DeepEqual(got.FoundSignatures, want) {
+		t.Errorf("FoundSignatures = %v, want %v", got.FoundSignatures, want)
+	}
+
+	line := got.Lines[0]
+	if line.BestSignature != "transfer(address,uint256)" || len(line.Signatures) != len(fourBytesCache["a9059cbb"]) {
+		t.Errorf("unexpected PUSH4 line signatures: %q %v", line.BestSignature, line.Signatures)
+	}
+}
+
+func TestDisassembler_UnorderedResolverNoAgeBonus(t *testing.T) {
+	candidates := []string{"LOCK8605463013()", "test266151307()"}
+	resolver := SignatureResolverFunc(func(ctx context.Context, selector []byte) ([]string, error) {
+		return candidates, nil
+	})
+
+	// PUSH4 0xffffffff STOP
+	got, err := NewDisassembler(WithSignatureResolver(resolver)).Disassemble("63ffffffff00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	sigs := got.Lines[0].Signatures
+	if len(sigs) != 2 || sigs[0].Signature != candidates[0] || sigs[0].Score != sigs[1].Score {
+		t.Errorf("unexpected PUSH4 line signatures: %v", sigs)
+	}
+}
+
+func TestIsNewestFirst(t *testing.T) {
+	db, err := OpenSelectorDB(filepath.Join(t.TempDir(), "selectors.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	tests := []struct {
+		name     string
+		resolver SignatureResolver
+		want     bool
+	}{
+		{name: "default", resolver: DefaultSignatureResolver(), want: true},
+		{name: "offline", resolver: OfflineSignatureResolver(), want: true},
+		{name: "selector_db", resolver: db, want: false},
+		{name: "chain_with_selector_db", resolver: NewChainResolver(NewFourBytesCacheResolver(), db), want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isNewestFirst(tt.resolver); got != tt.want {
+				t.Errorf("isNewestFirst() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
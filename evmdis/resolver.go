@@ -0,0 +1,161 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/kirillDanshin/evmtools/evmfuncs"
+)
+
+// SignatureResolver resolves 4-byte function selectors into text signatures.
+type SignatureResolver interface {
+	// Lookup returns the known text signatures for the given 4-byte selector.
+	// An empty result with nil error means that the selector is unknown to the resolver.
+	Lookup(ctx context.Context, selector []byte) ([]string, error)
+}
+
+// OrderedSignatureResolver is a SignatureResolver knowing whether its results are in 4byte.directory order
+// (newest first). Disassembler prefers the older candidates only if NewestFirst returns true, as the results
+// of other resolvers, e.g. SelectorDB, are in arbitrary order.
+type OrderedSignatureResolver interface {
+	SignatureResolver
+
+	// NewestFirst returns true if Lookup returns the newest signatures first.
+	NewestFirst() bool
+}
+
+func isNewestFirst(r SignatureResolver) bool {
+	o, ok := r.(OrderedSignatureResolver)
+
+	return ok && o.NewestFirst()
+}
+
+// SignatureResolverFunc is an adapter to allow the use of ordinary functions as signature resolvers.
+type SignatureResolverFunc func(ctx context.Context, selector []byte) ([]string, error)
+
+func (f SignatureResolverFunc) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	return f(ctx, selector)
+}
+
+// DefaultSignatureResolver returns the resolver used by disassemblers without explicitly configured one:
+// the static selector cache first, then the public 4byte.directory API.
+func DefaultSignatureResolver() SignatureResolver {
+	return NewChainResolver(
+		NewFourBytesCacheResolver(),
+		defaultFourByteDirectoryResolver,
+	)
+}
+
+// OfflineSignatureResolver returns a resolver that never does any network calls:
+// the well-known function dictionary first, then the static selector cache.
+func OfflineSignatureResolver() SignatureResolver {
+	return NewChainResolver(
+		NewWellKnownResolver(),
+		NewFourBytesCacheResolver(),
+	)
+}
+
+var defaultFourByteDirectoryResolver = NewFourByteDirectoryResolver(DefaultFourByteDirectoryURL, DefaultFourByteDirectoryTimeout)
+
+// ChainResolver queries resolvers in order and returns the first non-empty result.
+type ChainResolver struct {
+	resolvers []SignatureResolver
+}
+
+// NewChainResolver returns a resolver that queries given resolvers in order.
+// Nil resolvers are skipped.
+func NewChainResolver(resolvers ...SignatureResolver) *ChainResolver {
+	chain := &ChainResolver{}
+	for _, r := range resolvers {
+		if r != nil {
+			chain.resolvers = append(chain.resolvers, r)
+		}
+	}
+
+	return chain
+}
+
+// Lookup returns the result of the first resolver that knows the selector.
+// Errors of individual resolvers are skipped, the first of them is returned
+// only if no resolver knows the selector.
+func (c *ChainResolver) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	var firstErr error
+	for _, r := range c.resolvers {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+
+		sigs, err := r.Lookup(ctx, selector)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+
+		if len(sigs) > 0 {
+			return sigs, nil
+		}
+	}
+
+	return nil, firstErr
+}
+
+// NewestFirst returns true if all the resolvers return the newest signatures first.
+func (c *ChainResolver) NewestFirst() bool {
+	for _, r := range c.resolvers {
+		if !isNewestFirst(r) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// WellKnownResolver resolves selectors using the evmfuncs well-known function dictionary.
+type WellKnownResolver struct{}
+
+// NewWellKnownResolver returns a resolver backed by the evmfuncs well-known function dictionary.
+func NewWellKnownResolver() *WellKnownResolver {
+	return &WellKnownResolver{}
+}
+
+func (*WellKnownResolver) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	if err := checkSelector(selector); err != nil {
+		return nil, err
+	}
+
+	desc, ok := evmfuncs.GetWellKnownFuncByMethodID(selector)
+	if !ok {
+		return nil, nil
+	}
+
+	return []string{desc.Signature()}, nil
+}
+
+// NewestFirst returns true, as the dictionary holds a single signature per selector.
+func (*WellKnownResolver) NewestFirst() bool {
+	return true
+}
+
+func checkSelector(selector []byte) error {
+	if len(selector) != 4 {
+		return fmt.Errorf("evmdis: invalid selector length %d, expected 4", len(selector))
+	}
+
+	return nil
+}
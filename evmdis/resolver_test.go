@@ -0,0 +1,176 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestFourByteDirectoryResolver(t *testing.T) {
+	var calls int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if r.URL.Path != "/api/v1/signatures/" {
+			http.NotFound(w, r)
+			return
+		}
+
+		switch r.URL.Query().Get("hex_signature") {
+		case "0xa9059cbb":
+			fmt.Fprint(w, `{"count":2,"results":[{"id":2,"text_signature":"func_2093253501(bytes)"},{"id":1,"text_signature":"transfer(address,uint256)"}]}`)
+		case "0xdeadbeef":
+			fmt.Fprint(w, `{"count":0,"results":[]}`)
+		default:
+			http.Error(w, "boom", http.StatusInternalServerError)
+		}
+	}))
+	defer srv.Close()
+
+	tests := []struct {
+		name     string
+		selector []byte
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name:     "known",
+			selector: []byte{0xa9, 0x05, 0x9c, 0xbb},
+			want:     []string{"func_2093253501(bytes)", "transfer(address,uint256)"},
+		},
+		{
+			name:     "unknown",
+			selector: []byte{0xde, 0xad, 0xbe, 0xef},
+			want:     []string{},
+		},
+		{
+			name:     "server error",
+			selector: []byte{0x00, 0x00, 0x00, 0x01},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid selector",
+			selector: []byte{0x00},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := NewFourByteDirectoryResolver(srv.URL, time.Second)
+			got, err := r.Lookup(context.Background(), tt.selector)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("FourByteDirectoryResolver.Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !tt.wantErr && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("FourByteDirectoryResolver.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("memoized", func(t *testing.T) {
+		r := NewFourByteDirectoryResolver(srv.URL, time.Second)
+		before := atomic.LoadInt32(&calls)
+		for i := 0; i < 3; i++ {
+			if _, err := r.Lookup(context.Background(), []byte{0xa9, 0x05, 0x9c, 0xbb}); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if n := atomic.LoadInt32(&calls) - before; n != 1 {
+			t.Errorf("expected 1 request, got %d", n)
+		}
+	})
+}
+
+func TestChainResolver(t *testing.T) {
+	errFailing := errors.New("failing")
+	failing := SignatureResolverFunc(func(ctx context.Context, selector []byte) ([]string, error) {
+		return nil, errFailing
+	})
+	empty := SignatureResolverFunc(func(ctx context.Context, selector []byte) ([]string, error) {
+		return nil, nil
+	})
+
+	tests := []struct {
+		name      string
+		resolvers []SignatureResolver
+		selector  []byte
+		want      []string
+		wantErr   error
+	}{
+		{
+			name:      "well-known wins",
+			resolvers: []SignatureResolver{NewWellKnownResolver(), NewFourBytesCacheResolver()},
+			selector:  []byte{0xa9, 0x05, 0x9c, 0xbb},
+			want:      []string{"transfer(address,uint256)"},
+		},
+		{
+			name:      "falls through errors",
+			resolvers: []SignatureResolver{failing, empty, NewFourBytesCacheResolver()},
+			selector:  []byte{0x0e, 0x13, 0x6b, 0x19},
+			want:      []string{"deprecated()"},
+		},
+		{
+			name:      "reports error when unresolved",
+			resolvers: []SignatureResolver{empty, failing},
+			selector:  []byte{0xde, 0xad, 0xbe, 0xef},
+			wantErr:   errFailing,
+		},
+		{
+			name:      "unresolved",
+			resolvers: []SignatureResolver{empty, nil},
+			selector:  []byte{0xde, 0xad, 0xbe, 0xef},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewChainResolver(tt.resolvers...).Lookup(context.Background(), tt.selector)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("ChainResolver.Lookup() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ChainResolver.Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDisassembler_WithSignatureResolver(t *testing.T) {
+	var looked [][]byte
+	r := SignatureResolverFunc(func(ctx context.Context, selector []byte) ([]string, error) {
+		looked = append(looked, selector)
+		return []string{"transfer(address,uint256)"}, nil
+	})
+
+	// PUSH4 0xa9059cbb PUSH4 0xa9059cbb STOP
+	got, err := NewDisassembler(WithSignatureResolver(r)).Disassemble("63a9059cbb63a9059cbb00")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(looked) != 1 {
+		t.Errorf("expected a single lookup, got %d", len(looked))
+	}
+
+	if _, ok := got.FoundSignatures["transfer(address,uint256)"]; !ok {
+		t.Errorf("expected transfer(address,uint256) in found signatures, got %v", got.FoundSignatures)
+	}
+}
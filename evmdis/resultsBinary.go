@@ -0,0 +1,284 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+const resultsBinaryMagic = "EVMDISR"
+
+// ErrResultsCorrupted is returned by Results.UnmarshalBinary if the data is truncated or malformed.
+var ErrResultsCorrupted = errors.New("evmdis: corrupted binary results")
+
+// MarshalBinary encodes the results into a compact binary format.
+//
+// The format starts with the "EVMDISR" magic, the schema version and the fork byte,
+// followed by the uvarint-prefixed fields in the order of the Results struct.
+// Derived fields, like instruction mnemonics and stack effects, are not stored.
+func (r *Results) MarshalBinary() ([]byte, error) {
+	w := binaryWriter{buf: make([]byte, 0, 16+len(r.Lines)*8)}
+
+	w.buf = append(w.buf, resultsBinaryMagic...)
+	w.buf = append(w.buf, ResultsSchemaVersion, byte(r.Fork))
+	w.uvarint(uint64(r.CompiledLen))
+
+	w.uvarint(uint64(len(r.Lines)))
+	for _, line := range r.Lines {
+		w.uvarint(line.ProgramCounter)
+		w.buf = append(w.buf, byte(line.Inst.Code))
+		w.string(strings.Join(line.Args, ""))
+		w.string(line.Comment)
+		w.string(line.BestSignature)
+
+		w.uvarint(uint64(len(line.Signatures)))
+		for _, s := range line.Signatures {
+			w.string(s.Signature)
+			w.buf = binary.AppendVarint(w.buf, int64(s.Score))
+		}
+	}
+
+	sigs := r.sortedSignatures()
+	w.uvarint(uint64(len(sigs)))
+	for _, sig := range sigs {
+		w.string(sig)
+	}
+
+	if t := r.Dispatcher; t != nil {
+		w.buf = append(w.buf, 1, byte(t.Kind))
+		w.uvarint(t.SelectorPC)
+		w.uvarint(uint64(len(t.Entries)))
+		for _, e := range t.Entries {
+			w.buf = append(w.buf, e.Selector[:]...)
+			w.uvarint(e.EntryPC)
+			w.string(e.Signature)
+		}
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+
+	if md := r.Metadata; md != nil {
+		w.buf = append(w.buf, 1)
+		w.uvarint(uint64(md.Offset))
+		w.string(string(md.Raw))
+	} else {
+		w.buf = append(w.buf, 0)
+	}
+
+	w.uvarint(uint64(len(r.Diagnostics)))
+	for _, d := range r.Diagnostics {
+		w.uvarint(d.PC)
+		w.buf = append(w.buf, byte(d.Kind))
+		w.string(d.Reason)
+	}
+
+	return w.buf, nil
+}
+
+// UnmarshalBinary decodes the results encoded by MarshalBinary.
+func (r *Results) UnmarshalBinary(data []byte) error {
+	if !bytes.HasPrefix(data, []byte(resultsBinaryMagic)) || len(data) < len(resultsBinaryMagic)+2 {
+		return ErrResultsCorrupted
+	}
+
+	rd := binaryReader{data: data[len(resultsBinaryMagic):]}
+	if version := rd.byte(); version != ResultsSchemaVersion {
+		return fmt.Errorf("evmdis: unsupported results schema version %d", version)
+	}
+
+	fork := evmops.Fork(rd.byte())
+	if fork > evmops.LatestFork {
+		return fmt.Errorf("evmdis: unknown fork %d", fork)
+	}
+
+	instructions := evmops.ForkInstructionSet(fork)
+	res := Results{
+		CompiledLen:     int(rd.uvarint()),
+		FoundSignatures: map[string]struct{}{},
+		Fork:            fork,
+	}
+
+	n := rd.count()
+	res.Lines = make([]evmops.Line, 0, n)
+	for i := 0; i < n && rd.err == nil; i++ {
+		line := evmops.Line{ProgramCounter: rd.uvarint()}
+		line.Inst = instructions[rd.byte()]
+		if arg := rd.string(); arg != "" {
+			line.Args = []string{arg}
+		}
+		line.Comment = rd.string()
+		line.BestSignature = rd.string()
+
+		m := rd.count()
+		for j := 0; j < m && rd.err == nil; j++ {
+			line.Signatures = append(line.Signatures, ScoredSignature{
+				Signature: rd.string(),
+				Score:     int(rd.varint()),
+			})
+		}
+
+		res.Lines = append(res.Lines, line)
+	}
+
+	n = rd.count()
+	for i := 0; i < n && rd.err == nil; i++ {
+		res.FoundSignatures[rd.string()] = struct{}{}
+	}
+
+	if rd.byte() == 1 {
+		t := &DispatchTable{
+			Kind:       DispatcherKind(rd.byte()),
+			SelectorPC: rd.uvarint(),
+		}
+
+		n = rd.count()
+		for i := 0; i < n && rd.err == nil; i++ {
+			var e DispatchEntry
+			copy(e.Selector[:], rd.bytes(4))
+			e.EntryPC = rd.uvarint()
+			e.Signature = rd.string()
+			t.Entries = append(t.Entries, e)
+		}
+
+		res.Dispatcher = t
+	}
+
+	if rd.byte() == 1 {
+		offset := int(rd.uvarint())
+		raw := []byte(rd.string())
+		if rd.err == nil {
+			md, err := decodeRawMetadata(raw, offset)
+			if err != nil {
+				return err
+			}
+			res.Metadata = md
+		}
+	}
+
+	n = rd.count()
+	for i := 0; i < n && rd.err == nil; i++ {
+		res.Diagnostics = append(res.Diagnostics, Diagnostic{
+			PC:     rd.uvarint(),
+			Kind:   DiagnosticKind(rd.byte()),
+			Reason: rd.string(),
+		})
+	}
+
+	if rd.err != nil || len(rd.data) != 0 {
+		return ErrResultsCorrupted
+	}
+
+	*r = res
+
+	return nil
+}
+
+type binaryWriter struct {
+	buf []byte
+}
+
+func (w *binaryWriter) uvarint(v uint64) {
+	w.buf = binary.AppendUvarint(w.buf, v)
+}
+
+func (w *binaryWriter) string(s string) {
+	w.uvarint(uint64(len(s)))
+	w.buf = append(w.buf, s...)
+}
+
+// binaryReader reads the fields written by binaryWriter.
+// The first error is kept in err, and all the following reads return zero values.
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+func (r *binaryReader) fail() {
+	r.err = ErrResultsCorrupted
+	r.data = nil
+}
+
+func (r *binaryReader) byte() byte {
+	if len(r.data) == 0 {
+		r.fail()
+		return 0
+	}
+
+	b := r.data[0]
+	r.data = r.data[1:]
+
+	return b
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+
+	return v
+}
+
+func (r *binaryReader) varint() int64 {
+	v, n := binary.Varint(r.data)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.data = r.data[n:]
+
+	return v
+}
+
+// count reads a number of items, which can't exceed the number of the remaining bytes.
+func (r *binaryReader) count() int {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.fail()
+		return 0
+	}
+
+	return int(n)
+}
+
+func (r *binaryReader) bytes(n int) []byte {
+	if n > len(r.data) {
+		r.fail()
+		return nil
+	}
+
+	b := r.data[:n]
+	r.data = r.data[n:]
+
+	return b
+}
+
+func (r *binaryReader) string() string {
+	n := r.uvarint()
+	if n > uint64(len(r.data)) {
+		r.fail()
+		return ""
+	}
+
+	return string(r.bytes(int(n)))
+}
@@ -0,0 +1,329 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"sort"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// ResultsSchemaVersion is the version of the JSON and binary representations of Results.
+// It is incremented on every incompatible change of the schema.
+const ResultsSchemaVersion = 1
+
+type resultsJSON struct {
+	Version         int               `json:"version"`
+	Fork            string            `json:"fork"`
+	CompiledLen     int               `json:"compiled_len"`
+	Instructions    []instructionJSON `json:"instructions"`
+	FoundSignatures []string          `json:"found_signatures"`
+	Dispatcher      *dispatcherJSON   `json:"dispatcher,omitempty"`
+	Metadata        *metadataJSON     `json:"metadata,omitempty"`
+	Diagnostics     []diagnosticJSON  `json:"diagnostics,omitempty"`
+}
+
+type instructionJSON struct {
+	PC            uint64                `json:"pc"`
+	Opcode        evmops.Opcode         `json:"opcode"`
+	Mnemonic      string                `json:"mnemonic"`
+	Immediate     string                `json:"immediate,omitempty"`
+	Value         string                `json:"value,omitempty"`
+	StackIn       int                   `json:"stack_in"`
+	StackOut      int                   `json:"stack_out"`
+	Block         int                   `json:"block"`
+	Comment       string                `json:"comment,omitempty"`
+	BestSignature string                `json:"signature,omitempty"`
+	Signatures    []scoredSignatureJSON `json:"signatures,omitempty"`
+}
+
+type scoredSignatureJSON struct {
+	Signature string `json:"signature"`
+	Score     int    `json:"score"`
+}
+
+type dispatcherJSON struct {
+	Kind       string              `json:"kind"`
+	SelectorPC uint64              `json:"selector_pc"`
+	Entries    []dispatchEntryJSON `json:"entries"`
+}
+
+type dispatchEntryJSON struct {
+	Selector  string `json:"selector"`
+	EntryPC   uint64 `json:"entry_pc"`
+	Signature string `json:"signature,omitempty"`
+}
+
+type metadataJSON struct {
+	Offset         int    `json:"offset"`
+	Raw            string `json:"raw"`
+	Compiler       string `json:"compiler,omitempty"`
+	Version        string `json:"version,omitempty"`
+	SourceHashKind string `json:"source_hash_kind,omitempty"`
+	SourceHash     string `json:"source_hash,omitempty"`
+	Experimental   bool   `json:"experimental,omitempty"`
+}
+
+type diagnosticJSON struct {
+	PC     uint64 `json:"pc"`
+	Kind   string `json:"kind"`
+	Reason string `json:"reason"`
+}
+
+// MarshalJSON encodes the results into a stable JSON schema:
+//
+//	{
+//	  "version": 1,
+//	  "fork": "Cancun",
+//	  "compiled_len": 1234,
+//	  "instructions": [{"pc": 0, "opcode": 96, "mnemonic": "PUSH1", "immediate": "0x80", "value": "128",
+//	                    "stack_in": 0, "stack_out": 1, "block": 0}],
+//	  "found_signatures": ["transfer(address,uint256)"],
+//	  "dispatcher": {"kind": "shift", "selector_pc": 13, "entries": [{"selector": "0xa9059cbb", "entry_pc": 100}]},
+//	  "metadata": {"offset": 1200, "raw": "0xa264...0033", "compiler": "solc", "version": "0.8.10"},
+//	  "diagnostics": [{"pc": 1200, "kind": "metadata", "reason": "..."}]
+//	}
+//
+// Immediates are hex strings and decimal big integers, the block is the ID of the basic block
+// in the BuildCFG graph of the results. Found signatures are sorted.
+func (r *Results) MarshalJSON() ([]byte, error) {
+	out := resultsJSON{
+		Version:         ResultsSchemaVersion,
+		Fork:            r.Fork.String(),
+		CompiledLen:     r.CompiledLen,
+		Instructions:    make([]instructionJSON, 0, len(r.Lines)),
+		FoundSignatures: r.sortedSignatures(),
+	}
+
+	blocks := map[uint64]int{}
+	for _, b := range BuildCFG(r).Blocks {
+		for _, line := range b.Lines {
+			blocks[line.ProgramCounter] = b.ID
+		}
+	}
+
+	for _, line := range r.Lines {
+		inst := instructionJSON{
+			PC:            line.ProgramCounter,
+			Opcode:        line.Inst.Code,
+			Mnemonic:      line.Inst.Mnemonic,
+			StackIn:       line.Inst.InCount,
+			StackOut:      line.Inst.OutCount,
+			Block:         blocks[line.ProgramCounter],
+			Comment:       line.Comment,
+			BestSignature: line.BestSignature,
+		}
+
+		if arg := []byte(strings.Join(line.Args, "")); len(arg) > 0 {
+			inst.Immediate = "0x" + hex.EncodeToString(arg)
+			inst.Value = new(big.Int).SetBytes(arg).String()
+		}
+
+		for _, s := range line.Signatures {
+			inst.Signatures = append(inst.Signatures, scoredSignatureJSON(s))
+		}
+
+		out.Instructions = append(out.Instructions, inst)
+	}
+
+	if t := r.Dispatcher; t != nil {
+		out.Dispatcher = &dispatcherJSON{
+			Kind:       t.Kind.String(),
+			SelectorPC: t.SelectorPC,
+			Entries:    make([]dispatchEntryJSON, 0, len(t.Entries)),
+		}
+		for _, e := range t.Entries {
+			out.Dispatcher.Entries = append(out.Dispatcher.Entries, dispatchEntryJSON{
+				Selector:  "0x" + e.SelectorHex(),
+				EntryPC:   e.EntryPC,
+				Signature: e.Signature,
+			})
+		}
+	}
+
+	if md := r.Metadata; md != nil {
+		out.Metadata = &metadataJSON{
+			Offset:         md.Offset,
+			Raw:            "0x" + hex.EncodeToString(md.Raw),
+			Compiler:       md.Compiler,
+			Version:        md.Version,
+			SourceHashKind: md.SourceHashKind,
+			Experimental:   md.Experimental,
+		}
+		if len(md.SourceHash) > 0 {
+			out.Metadata.SourceHash = "0x" + hex.EncodeToString(md.SourceHash)
+		}
+	}
+
+	for _, d := range r.Diagnostics {
+		out.Diagnostics = append(out.Diagnostics, diagnosticJSON{
+			PC:     d.PC,
+			Kind:   d.Kind.String(),
+			Reason: d.Reason,
+		})
+	}
+
+	return json.Marshal(out)
+}
+
+// UnmarshalJSON decodes the results encoded by MarshalJSON.
+// The instructions are restored from the instruction set of the encoded fork,
+// the derived fields like mnemonics, stack effects and blocks are ignored.
+func (r *Results) UnmarshalJSON(data []byte) error {
+	var in resultsJSON
+	if err := json.Unmarshal(data, &in); err != nil {
+		return err
+	}
+
+	if in.Version != ResultsSchemaVersion {
+		return fmt.Errorf("evmdis: unsupported results schema version %d", in.Version)
+	}
+
+	fork, ok := evmops.ForkByName(in.Fork)
+	if !ok {
+		return fmt.Errorf("evmdis: unknown fork %q", in.Fork)
+	}
+
+	instructions := evmops.ForkInstructionSet(fork)
+	res := Results{
+		CompiledLen:     in.CompiledLen,
+		Lines:           make([]evmops.Line, 0, len(in.Instructions)),
+		FoundSignatures: make(map[string]struct{}, len(in.FoundSignatures)),
+		Fork:            fork,
+	}
+
+	for _, inst := range in.Instructions {
+		line := evmops.Line{
+			Inst:           instructions[inst.Opcode],
+			ProgramCounter: inst.PC,
+			Comment:        inst.Comment,
+			BestSignature:  inst.BestSignature,
+		}
+
+		if inst.Immediate != "" {
+			arg, err := decodeHex0x(inst.Immediate)
+			if err != nil {
+				return fmt.Errorf("evmdis: invalid immediate at pc=%d: %w", inst.PC, err)
+			}
+			line.Args = []string{string(arg)}
+		}
+
+		for _, s := range inst.Signatures {
+			line.Signatures = append(line.Signatures, ScoredSignature(s))
+		}
+
+		res.Lines = append(res.Lines, line)
+	}
+
+	for _, sig := range in.FoundSignatures {
+		res.FoundSignatures[sig] = struct{}{}
+	}
+
+	if in.Dispatcher != nil {
+		kind, ok := dispatcherKindByName(in.Dispatcher.Kind)
+		if !ok {
+			return fmt.Errorf("evmdis: unknown dispatcher kind %q", in.Dispatcher.Kind)
+		}
+
+		res.Dispatcher = &DispatchTable{
+			Kind:       kind,
+			SelectorPC: in.Dispatcher.SelectorPC,
+		}
+		for _, e := range in.Dispatcher.Entries {
+			sel, err := decodeHex0x(e.Selector)
+			if err != nil || len(sel) != 4 {
+				return fmt.Errorf("evmdis: invalid dispatcher selector %q", e.Selector)
+			}
+
+			entry := DispatchEntry{EntryPC: e.EntryPC, Signature: e.Signature}
+			copy(entry.Selector[:], sel)
+			res.Dispatcher.Entries = append(res.Dispatcher.Entries, entry)
+		}
+	}
+
+	if in.Metadata != nil {
+		raw, err := decodeHex0x(in.Metadata.Raw)
+		if err != nil {
+			return fmt.Errorf("evmdis: invalid metadata: %w", err)
+		}
+
+		if res.Metadata, err = decodeRawMetadata(raw, in.Metadata.Offset); err != nil {
+			return err
+		}
+	}
+
+	for _, d := range in.Diagnostics {
+		kind, ok := diagnosticKindByName(d.Kind)
+		if !ok {
+			return fmt.Errorf("evmdis: unknown diagnostic kind %q", d.Kind)
+		}
+
+		res.Diagnostics = append(res.Diagnostics, Diagnostic{PC: d.PC, Kind: kind, Reason: d.Reason})
+	}
+
+	*r = res
+
+	return nil
+}
+
+func (r *Results) sortedSignatures() []string {
+	sigs := make([]string, 0, len(r.FoundSignatures))
+	for sig := range r.FoundSignatures {
+		sigs = append(sigs, sig)
+	}
+	sort.Strings(sigs)
+
+	return sigs
+}
+
+// decodeRawMetadata decodes the metadata stored at the given offset of the code.
+func decodeRawMetadata(raw []byte, offset int) (*Metadata, error) {
+	md, err := DecodeMetadata(raw)
+	if err != nil || md.Offset != 0 {
+		return nil, fmt.Errorf("evmdis: invalid metadata")
+	}
+	md.Offset = offset
+
+	return md, nil
+}
+
+func decodeHex0x(s string) ([]byte, error) {
+	return hex.DecodeString(strings.TrimPrefix(s, "0x"))
+}
+
+func dispatcherKindByName(name string) (DispatcherKind, bool) {
+	for k := DispatcherUnknown; k <= DispatcherVyperMemory; k++ {
+		if k.String() == name {
+			return k, true
+		}
+	}
+
+	return 0, false
+}
+
+func diagnosticKindByName(name string) (DiagnosticKind, bool) {
+	for k := DiagnosticTruncatedPush; k <= DiagnosticStackOverflow; k++ {
+		if k.String() == name {
+			return k, true
+		}
+	}
+
+	return 0, false
+}
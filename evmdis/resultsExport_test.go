@@ -0,0 +1,140 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+)
+
+func TestResults_MarshalJSON(t *testing.T) {
+	r := disassembleOffline(t, "6080604052"+"63a9059cbb"+"600f"+"56"+"fe")
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got struct {
+		Version      int    `json:"version"`
+		Fork         string `json:"fork"`
+		Instructions []struct {
+			PC        uint64 `json:"pc"`
+			Opcode    int    `json:"opcode"`
+			Mnemonic  string `json:"mnemonic"`
+			Immediate string `json:"immediate"`
+			Value     string `json:"value"`
+			StackIn   int    `json:"stack_in"`
+			StackOut  int    `json:"stack_out"`
+			Block     int    `json:"block"`
+			Signature string `json:"signature"`
+		} `json:"instructions"`
+		FoundSignatures []string `json:"found_signatures"`
+	}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatal(err)
+	}
+
+	if got.Version != ResultsSchemaVersion || got.Fork != "Prague" || len(got.Instructions) != 7 {
+		t.Fatalf("unexpected results: %s", data)
+	}
+
+	push := got.Instructions[0]
+	if push.Opcode != 0x60 || push.Mnemonic != "PUSH1" || push.Immediate != "0x80" || push.Value != "128" || push.StackOut != 1 {
+		t.Errorf("unexpected PUSH1: %+v", push)
+	}
+
+	selector := got.Instructions[3]
+	if selector.Value != "2835717307" || selector.Signature != "transfer(address,uint256)" {
+		t.Errorf("unexpected PUSH4: %+v", selector)
+	}
+
+	if mstore := got.Instructions[2]; mstore.StackIn != 2 || mstore.Immediate != "" {
+		t.Errorf("unexpected MSTORE: %+v", mstore)
+	}
+
+	if last := got.Instructions[6]; last.Block != 1 {
+		t.Errorf("INVALID is in block %d, want 1", last.Block)
+	}
+
+	if len(got.FoundSignatures) != 1 || got.FoundSignatures[0] != "transfer(address,uint256)" {
+		t.Errorf("unexpected found signatures: %v", got.FoundSignatures)
+	}
+}
+
+func TestResults_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"uniswap", uniswapRuntimeCode},
+		{"goerli_test_token", goerliTestTokenCreationCode},
+		{"diagnostics", "600056fe0c0d0e5b60"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := disassembleOffline(t, tt.code)
+
+			want, err := json.Marshal(r)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fromJSON Results
+			if err := json.Unmarshal(want, &fromJSON); err != nil {
+				t.Fatalf("Results.UnmarshalJSON() error = %v", err)
+			}
+
+			got, err := json.Marshal(&fromJSON)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Error("JSON round-trip changed the results")
+			}
+
+			bin, err := r.MarshalBinary()
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var fromBinary Results
+			if err := fromBinary.UnmarshalBinary(bin); err != nil {
+				t.Fatalf("Results.UnmarshalBinary() error = %v", err)
+			}
+
+			got, err = json.Marshal(&fromBinary)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Error("binary round-trip changed the results")
+			}
+
+			if fromBinary.Implements(ERC20Iface) != r.Implements(ERC20Iface) {
+				t.Error("binary round-trip changed the interface detection")
+			}
+
+			if len(bin) > 1 {
+				var truncated Results
+				if err := truncated.UnmarshalBinary(bin[:len(bin)-1]); !errors.Is(err, ErrResultsCorrupted) {
+					t.Errorf("Results.UnmarshalBinary() of truncated data error = %v", err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,82 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmasm"
+)
+
+// TestAssemblerRoundTrip disassembles the test contracts and assembles them back.
+// The metadata is not disassembled, so it is excluded from the comparison.
+func TestAssemblerRoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"usdt", usdtCreationCode},
+		{"uniswap", uniswapRuntimeCode},
+		{"ens", ensRuntimeCode},
+		{"goerli_test_token", goerliTestTokenCreationCode},
+		{"truncated push", "600160ff61aa"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := disassembleOffline(t, tt.code)
+
+			want, err := hex.DecodeString(tt.code)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if r.Metadata != nil {
+				want = want[:r.Metadata.Offset]
+			}
+
+			a := evmasm.NewAssembler()
+
+			got, err := a.AssembleLines(r.Lines)
+			if err != nil {
+				t.Fatalf("Assembler.AssembleLines() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Assembler.AssembleLines() differs from the original code, got %d bytes, want %d", len(got), len(want))
+			}
+
+			got, err = a.AssembleListing(r.String())
+			if err != nil {
+				t.Fatalf("Assembler.AssembleListing() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Assembler.AssembleListing() differs from the original code, got %d bytes, want %d", len(got), len(want))
+			}
+
+			src, err := r.Format(AsmFormatter{})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err = a.Assemble(src)
+			if err != nil {
+				t.Fatalf("Assembler.Assemble() error = %v", err)
+			}
+			if !bytes.Equal(got, want) {
+				t.Errorf("Assembler.Assemble() of AsmFormatter output differs from the original code, got %d bytes, want %d", len(got), len(want))
+			}
+		})
+	}
+}
@@ -0,0 +1,838 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/kirillDanshin/evmtools"
+)
+
+// Selector database file layout (all integers are big endian):
+//
+//	header  magic "EVMSIGDB", version uint32, count uint32, index offset uint64
+//	records count times: uvarint signature length, signature
+//	index   count times: selector [4]byte, record offset uint32
+//
+// Records and index entries are sorted by selector, then by signature,
+// so lookups are a binary search over the index with no data loaded into memory.
+// Entries added after the last compaction are kept in a plain text journal
+// next to the database file, one signature per line.
+const (
+	selectorDBMagic      = "EVMSIGDB"
+	selectorDBVersion    = 1
+	selectorDBHeaderSize = 24
+	selectorDBIndexEntry = 8
+
+	selectorDBJournalSuffix = ".journal"
+)
+
+var ErrSelectorDBCorrupted = errors.New("evmdis: selector database is corrupted")
+
+// ErrSelectorDBUnusable is returned by all the operations of a SelectorDB
+// whose file couldn't be reopened after a failed rewrite.
+var ErrSelectorDBUnusable = errors.New("evmdis: selector database file can't be reopened")
+
+// SelectorDB is a file-backed selector to signature database.
+// It implements SignatureResolver, so it can be plugged into a Disassembler.
+// SelectorDB is safe for concurrent use within a single process.
+type SelectorDB struct {
+	path string
+
+	mu       sync.RWMutex
+	f        *os.File
+	count    int
+	indexOff int64
+
+	// broken is set if the database file couldn't be reopened, see ErrSelectorDBUnusable
+	broken error
+
+	journal *os.File
+	overlay map[[4]byte][]string
+	pending int
+}
+
+type selectorDBEntry struct {
+	selector  [4]byte
+	signature string
+}
+
+func lessSelectorDBEntry(a, b selectorDBEntry) bool {
+	if c := bytes.Compare(a.selector[:], b.selector[:]); c != 0 {
+		return c < 0
+	}
+
+	return a.signature < b.signature
+}
+
+// OpenSelectorDB opens the selector database at path, creating an empty one if it does not exist.
+func OpenSelectorDB(path string) (*SelectorDB, error) {
+	db := &SelectorDB{
+		path:    path,
+		overlay: map[[4]byte][]string{},
+	}
+
+	if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+		if err := createEmptySelectorDB(path); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := db.openMain(); err != nil {
+		return nil, err
+	}
+
+	if err := db.openJournal(); err != nil {
+		db.f.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+func (db *SelectorDB) openMain() error {
+	f, err := os.Open(db.path)
+	if err != nil {
+		return err
+	}
+
+	var hdr [selectorDBHeaderSize]byte
+	if _, err := io.ReadFull(f, hdr[:]); err != nil {
+		f.Close()
+		return fmt.Errorf("%w: %v", ErrSelectorDBCorrupted, err)
+	}
+
+	if string(hdr[:8]) != selectorDBMagic || binary.BigEndian.Uint32(hdr[8:12]) != selectorDBVersion {
+		f.Close()
+		return fmt.Errorf("%w: unknown file format", ErrSelectorDBCorrupted)
+	}
+
+	st, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return err
+	}
+
+	count := int64(binary.BigEndian.Uint32(hdr[12:16]))
+	indexOff := int64(binary.BigEndian.Uint64(hdr[16:24]))
+	if indexOff < selectorDBHeaderSize || indexOff+count*selectorDBIndexEntry != st.Size() {
+		f.Close()
+		return fmt.Errorf("%w: invalid index bounds", ErrSelectorDBCorrupted)
+	}
+
+	db.f = f
+	db.count = int(count)
+	db.indexOff = indexOff
+
+	return nil
+}
+
+func (db *SelectorDB) openJournal() error {
+	j, err := os.OpenFile(db.path+selectorDBJournalSuffix, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return err
+	}
+
+	sc := bufio.NewScanner(j)
+	for sc.Scan() {
+		sig := strings.TrimSpace(sc.Text())
+		if !isValidTextSignature(sig) {
+			continue
+		}
+
+		// the journal may outlive an interrupted compaction
+		known, err := db.lookupMain(selectorOf(sig))
+		if err != nil {
+			j.Close()
+			return err
+		}
+
+		if !containsString(known, sig) {
+			db.addToOverlay(sig)
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		j.Close()
+		return err
+	}
+
+	db.journal = j
+
+	return nil
+}
+
+// Close closes the database files.
+func (db *SelectorDB) Close() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	var err error
+	if db.broken == nil {
+		err = db.f.Close()
+	}
+
+	if jerr := db.journal.Close(); err == nil {
+		err = jerr
+	}
+
+	return err
+}
+
+// Len returns the number of entries in the database.
+func (db *SelectorDB) Len() int {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	return db.count + db.pending
+}
+
+// Lookup returns all signatures stored for the given selector.
+func (db *SelectorDB) Lookup(ctx context.Context, selector []byte) ([]string, error) {
+	if err := checkSelector(selector); err != nil {
+		return nil, err
+	}
+
+	var sel [4]byte
+	copy(sel[:], selector)
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if db.broken != nil {
+		return nil, db.broken
+	}
+
+	sigs, err := db.lookupMain(sel)
+	if err != nil {
+		return nil, err
+	}
+
+	if extra := db.overlay[sel]; len(extra) > 0 {
+		sigs = append(sigs, extra...)
+		sort.Strings(sigs)
+	}
+
+	return sigs, nil
+}
+
+func (db *SelectorDB) readIndexEntry(i int) ([4]byte, uint32, error) {
+	var buf [selectorDBIndexEntry]byte
+	var sel [4]byte
+	if _, err := db.f.ReadAt(buf[:], db.indexOff+int64(i)*selectorDBIndexEntry); err != nil {
+		return sel, 0, err
+	}
+
+	copy(sel[:], buf[:4])
+
+	return sel, binary.BigEndian.Uint32(buf[4:]), nil
+}
+
+func (db *SelectorDB) readRecord(off uint32) (string, error) {
+	r := bufio.NewReaderSize(io.NewSectionReader(db.f, int64(off), db.indexOff-int64(off)), 256)
+
+	return readSelectorDBRecord(r)
+}
+
+func readSelectorDBRecord(r *bufio.Reader) (string, error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return "", err
+	}
+
+	buf := make([]byte, n)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		return "", err
+	}
+
+	return string(buf), nil
+}
+
+func (db *SelectorDB) lookupMain(sel [4]byte) ([]string, error) {
+	var searchErr error
+	i := sort.Search(db.count, func(i int) bool {
+		got, _, err := db.readIndexEntry(i)
+		if err != nil && searchErr == nil {
+			searchErr = err
+		}
+
+		return bytes.Compare(got[:], sel[:]) >= 0
+	})
+	if searchErr != nil {
+		return nil, searchErr
+	}
+
+	var sigs []string
+	for ; i < db.count; i++ {
+		got, off, err := db.readIndexEntry(i)
+		if err != nil {
+			return nil, err
+		}
+
+		if got != sel {
+			break
+		}
+
+		sig, err := db.readRecord(off)
+		if err != nil {
+			return nil, err
+		}
+
+		sigs = append(sigs, sig)
+	}
+
+	return sigs, nil
+}
+
+// LookupName returns all signatures of functions with the given name.
+// The whole database is scanned, so it is much slower than Lookup.
+func (db *SelectorDB) LookupName(ctx context.Context, name string) ([]string, error) {
+	prefix := name + "("
+
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	if db.broken != nil {
+		return nil, db.broken
+	}
+
+	var sigs []string
+	err := db.iterateMain(func(e selectorDBEntry) error {
+		if strings.HasPrefix(e.signature, prefix) {
+			sigs = append(sigs, e.signature)
+		}
+
+		return ctx.Err()
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for _, extra := range db.overlay {
+		for _, sig := range extra {
+			if strings.HasPrefix(sig, prefix) {
+				sigs = append(sigs, sig)
+			}
+		}
+	}
+
+	sort.Strings(sigs)
+
+	return sigs, nil
+}
+
+// iterateMain calls fn for every entry of the compacted database file in order.
+func (db *SelectorDB) iterateMain(fn func(e selectorDBEntry) error) error {
+	records := bufio.NewReader(io.NewSectionReader(db.f, selectorDBHeaderSize, db.indexOff-selectorDBHeaderSize))
+	index := bufio.NewReader(io.NewSectionReader(db.f, db.indexOff, int64(db.count)*selectorDBIndexEntry))
+
+	var buf [selectorDBIndexEntry]byte
+	for i := 0; i < db.count; i++ {
+		if _, err := io.ReadFull(index, buf[:]); err != nil {
+			return err
+		}
+
+		sig, err := readSelectorDBRecord(records)
+		if err != nil {
+			return err
+		}
+
+		e := selectorDBEntry{signature: sig}
+		copy(e.selector[:], buf[:4])
+		if err := fn(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Add adds the given text signatures to the database journal and returns the number of new entries.
+// Invalid signatures are skipped. Journal entries are merged into the database file by Compact.
+func (db *SelectorDB) Add(sigs ...string) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	if db.broken != nil {
+		return 0, db.broken
+	}
+
+	var buf bytes.Buffer
+	added := 0
+	for _, sig := range sigs {
+		sig = strings.TrimSpace(sig)
+		if !isValidTextSignature(sig) {
+			continue
+		}
+
+		sel := selectorOf(sig)
+		known, err := db.lookupMain(sel)
+		if err != nil {
+			return added, err
+		}
+
+		if containsString(known, sig) || containsString(db.overlay[sel], sig) {
+			continue
+		}
+
+		buf.WriteString(sig)
+		buf.WriteByte('\n')
+		db.addToOverlay(sig)
+		added++
+	}
+
+	if buf.Len() == 0 {
+		return added, nil
+	}
+
+	if _, err := db.journal.Write(buf.Bytes()); err != nil {
+		return added, err
+	}
+
+	return added, db.journal.Sync()
+}
+
+func (db *SelectorDB) addToOverlay(sig string) {
+	sel := selectorOf(sig)
+	if containsString(db.overlay[sel], sig) {
+		return
+	}
+
+	db.overlay[sel] = append(db.overlay[sel], sig)
+	db.pending++
+}
+
+// Compact merges journal entries into the database file and truncates the journal.
+func (db *SelectorDB) Compact() error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	_, err := db.rewrite(nil)
+
+	return err
+}
+
+// ImportFourByteJSON imports signatures from a 4byte.directory or Etherface JSON export
+// and returns the number of new entries.
+// Both API pages (objects with "results" or "items" arrays) and plain arrays of signature
+// records are accepted. Records with a selector not matching the signature are skipped.
+func (db *SelectorDB) ImportFourByteJSON(r io.Reader) (int, error) {
+	dec := json.NewDecoder(r)
+
+	entries := db.newSorter()
+	defer entries.close()
+
+	add := func(rec *importRecord) error {
+		if e, ok := rec.entry(); ok {
+			return entries.add(e)
+		}
+
+		return nil
+	}
+
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+
+	switch tok {
+	case json.Delim('['):
+		if err := decodeImportRecords(dec, add); err != nil {
+			return 0, err
+		}
+	case json.Delim('{'):
+		for dec.More() {
+			key, err := dec.Token()
+			if err != nil {
+				return 0, err
+			}
+
+			if key != "results" && key != "items" {
+				var skip json.RawMessage
+				if err := dec.Decode(&skip); err != nil {
+					return 0, err
+				}
+				continue
+			}
+
+			if tok, err := dec.Token(); err != nil {
+				return 0, err
+			} else if tok != json.Delim('[') {
+				return 0, fmt.Errorf("evmdis: unexpected %v in %q", tok, key)
+			}
+
+			if err := decodeImportRecords(dec, add); err != nil {
+				return 0, err
+			}
+		}
+	default:
+		return 0, fmt.Errorf("evmdis: unexpected %v at the beginning of selector dump", tok)
+	}
+
+	return db.importEntries(entries)
+}
+
+// ImportCSV imports "selector,signature" lines and returns the number of new entries.
+// The selector column is optional, lines holding only a signature are accepted as well.
+// Empty lines, lines starting with '#' and invalid records (including a header line) are skipped.
+func (db *SelectorDB) ImportCSV(r io.Reader) (int, error) {
+	entries := db.newSorter()
+	defer entries.close()
+
+	sc := bufio.NewScanner(r)
+	sc.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		rec := &importRecord{TextSignature: line}
+		if sel, sig, ok := strings.Cut(line, ","); ok && !strings.Contains(sel, "(") {
+			rec.HexSignature, rec.TextSignature = strings.TrimSpace(sel), strings.Trim(strings.TrimSpace(sig), `"`)
+		}
+
+		if e, ok := rec.entry(); ok {
+			if err := entries.add(e); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := sc.Err(); err != nil {
+		return 0, err
+	}
+
+	return db.importEntries(entries)
+}
+
+// Import merges the given text signatures directly into the database file
+// and returns the number of new entries. Invalid signatures are skipped.
+// Unlike Add, it rewrites the database file, so it should be used for bulk updates.
+// The imported entries, as well as those of ImportFourByteJSON and ImportCSV, are sorted in chunks
+// of bounded size spilled to temporary files next to the database file, so large dumps aren't held in memory.
+func (db *SelectorDB) Import(sigs ...string) (int, error) {
+	entries := db.newSorter()
+	defer entries.close()
+
+	for _, sig := range sigs {
+		sig = strings.TrimSpace(sig)
+		if !isValidTextSignature(sig) {
+			continue
+		}
+
+		if err := entries.add(selectorDBEntry{selector: selectorOf(sig), signature: sig}); err != nil {
+			return 0, err
+		}
+	}
+
+	return db.importEntries(entries)
+}
+
+func (db *SelectorDB) importEntries(entries *selectorDBSorter) (int, error) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	return db.rewrite(entries)
+}
+
+// rewrite merges the database file, the journal overlay and the imported entries, if any, into a new database file.
+// It returns the number of entries that were not present in the database file or the journal before.
+func (db *SelectorDB) rewrite(imported *selectorDBSorter) (int, error) {
+	if db.broken != nil {
+		return 0, db.broken
+	}
+
+	var journal []selectorDBEntry
+	for sel, sigs := range db.overlay {
+		for _, sig := range sigs {
+			journal = append(journal, selectorDBEntry{selector: sel, signature: sig})
+		}
+	}
+	sortSelectorDBEntries(journal)
+
+	seqs := []selectorDBSeq{sliceSelectorDBSeq(journal)}
+	if imported != nil {
+		chunks, err := imported.seqs()
+		if err != nil {
+			return 0, err
+		}
+		seqs = append(seqs, chunks...)
+	}
+
+	extra, err := mergeSelectorDBSeqs(seqs)
+	if err != nil {
+		return 0, err
+	}
+
+	tmp := db.path + ".tmp"
+	out, err := os.Create(tmp)
+	if err != nil {
+		return 0, err
+	}
+	defer os.Remove(tmp)
+
+	w := newSelectorDBWriter(out)
+
+	merged := 0
+	var last *selectorDBEntry
+	write := func(e selectorDBEntry) error {
+		if last != nil && *last == e {
+			return nil
+		}
+		last = &e
+		merged++
+
+		return w.write(e)
+	}
+
+	// writeExtra writes the extra entries ordered before e, or all of them if e is nil
+	writeExtra := func(e *selectorDBEntry) error {
+		for extra.Len() > 0 && (e == nil || lessSelectorDBEntry(extra.peek(), *e)) {
+			if err := write(extra.peek()); err != nil {
+				return err
+			}
+
+			if err := extra.next(); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	err = db.iterateMain(func(e selectorDBEntry) error {
+		if err := writeExtra(&e); err != nil {
+			return err
+		}
+
+		return write(e)
+	})
+	if err == nil {
+		err = writeExtra(nil)
+	}
+
+	if err == nil {
+		err = w.finish()
+	}
+
+	if cerr := out.Close(); err == nil {
+		err = cerr
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	// journal entries were already reported as added by Add
+	added := merged - db.count - db.pending
+
+	// the database file is closed before it is replaced, as open files can't be replaced on some platforms
+	err = db.f.Close()
+	if err == nil {
+		err = os.Rename(tmp, db.path)
+	}
+
+	// the original file is reopened if it wasn't replaced
+	if oerr := db.openMain(); oerr != nil {
+		db.broken = fmt.Errorf("%w: %v", ErrSelectorDBUnusable, oerr)
+		return 0, db.broken
+	}
+
+	if err != nil {
+		return 0, err
+	}
+
+	if err := db.journal.Truncate(0); err != nil {
+		return 0, err
+	}
+
+	db.overlay = map[[4]byte][]string{}
+	db.pending = 0
+
+	return added, nil
+}
+
+type selectorDBWriter struct {
+	f       *os.File
+	w       *bufio.Writer
+	off     int64
+	index   []byte
+	scratch [binary.MaxVarintLen64]byte
+}
+
+func newSelectorDBWriter(f *os.File) *selectorDBWriter {
+	w := &selectorDBWriter{
+		f:   f,
+		w:   bufio.NewWriterSize(f, 64*1024),
+		off: selectorDBHeaderSize,
+	}
+
+	// header placeholder, it is filled in by finish
+	w.w.Write(make([]byte, selectorDBHeaderSize))
+
+	return w
+}
+
+func (w *selectorDBWriter) write(e selectorDBEntry) error {
+	if w.off > int64(^uint32(0)) {
+		return errors.New("evmdis: selector database is too large")
+	}
+
+	w.index = append(w.index, e.selector[:]...)
+	w.index = binary.BigEndian.AppendUint32(w.index, uint32(w.off))
+
+	n := binary.PutUvarint(w.scratch[:], uint64(len(e.signature)))
+	if _, err := w.w.Write(w.scratch[:n]); err != nil {
+		return err
+	}
+
+	if _, err := w.w.WriteString(e.signature); err != nil {
+		return err
+	}
+
+	w.off += int64(n + len(e.signature))
+
+	return nil
+}
+
+func (w *selectorDBWriter) finish() error {
+	if _, err := w.w.Write(w.index); err != nil {
+		return err
+	}
+
+	if err := w.w.Flush(); err != nil {
+		return err
+	}
+
+	var hdr [selectorDBHeaderSize]byte
+	copy(hdr[:8], selectorDBMagic)
+	binary.BigEndian.PutUint32(hdr[8:12], selectorDBVersion)
+	binary.BigEndian.PutUint32(hdr[12:16], uint32(len(w.index)/selectorDBIndexEntry))
+	binary.BigEndian.PutUint64(hdr[16:24], uint64(w.off))
+
+	if _, err := w.f.WriteAt(hdr[:], 0); err != nil {
+		return err
+	}
+
+	return w.f.Sync()
+}
+
+func createEmptySelectorDB(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+
+	if err := newSelectorDBWriter(f).finish(); err != nil {
+		f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+// importRecord is a signature record of 4byte.directory and Etherface dumps.
+type importRecord struct {
+	TextSignature string `json:"text_signature"`
+	HexSignature  string `json:"hex_signature"`
+
+	// Etherface field names
+	Text string `json:"text"`
+	Hash string `json:"hash"`
+}
+
+func (rec *importRecord) entry() (selectorDBEntry, bool) {
+	sig, selHex := rec.TextSignature, rec.HexSignature
+	if sig == "" {
+		sig, selHex = rec.Text, rec.Hash
+	}
+
+	sig = strings.TrimSpace(sig)
+	if !isValidTextSignature(sig) {
+		return selectorDBEntry{}, false
+	}
+
+	e := selectorDBEntry{selector: selectorOf(sig), signature: sig}
+	if selHex == "" {
+		return e, true
+	}
+
+	selHex = strings.TrimPrefix(strings.ToLower(selHex), "0x")
+	if len(selHex) > 8 {
+		// Etherface stores full keccak hashes
+		selHex = selHex[:8]
+	}
+
+	if selHex != hex.EncodeToString(e.selector[:]) {
+		return selectorDBEntry{}, false
+	}
+
+	return e, true
+}
+
+func decodeImportRecords(dec *json.Decoder, fn func(rec *importRecord) error) error {
+	for dec.More() {
+		var rec importRecord
+		if err := dec.Decode(&rec); err != nil {
+			return err
+		}
+
+		if err := fn(&rec); err != nil {
+			return err
+		}
+	}
+
+	// closing bracket
+	_, err := dec.Token()
+
+	return err
+}
+
+func selectorOf(sig string) [4]byte {
+	var sel [4]byte
+	copy(sel[:], evmtools.MethodID(sig))
+
+	return sel
+}
+
+// isValidTextSignature reports whether sig looks like a canonical text signature, e.g. "transfer(address,uint256)".
+func isValidTextSignature(sig string) bool {
+	open := strings.IndexByte(sig, '(')
+
+	return open > 0 && strings.HasSuffix(sig, ")") && !strings.ContainsAny(sig, " \t\r\n")
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,222 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bufio"
+	"container/heap"
+	"encoding/binary"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// selectorDBSortChunk is the number of imported entries sorted in memory before they are spilled to a file.
+// It's a variable for tests.
+var selectorDBSortChunk = 1 << 16
+
+// selectorDBSorter sorts the imported entries in bounded memory: every selectorDBSortChunk entries
+// are sorted and written to a temporary file, and the files are merged by seqs.
+//
+// Chunk file records are: selector [4]byte, uvarint signature length, signature.
+type selectorDBSorter struct {
+	dir    string
+	prefix string
+
+	chunk []selectorDBEntry
+	files []*os.File
+}
+
+// newSorter returns a sorter of the entries imported into the database.
+// The chunks are kept next to the database file rather than in the system temporary directory,
+// which may be backed by memory.
+func (db *SelectorDB) newSorter() *selectorDBSorter {
+	return &selectorDBSorter{
+		dir:    filepath.Dir(db.path),
+		prefix: filepath.Base(db.path) + ".import-",
+	}
+}
+
+func (s *selectorDBSorter) add(e selectorDBEntry) error {
+	s.chunk = append(s.chunk, e)
+	if len(s.chunk) < selectorDBSortChunk {
+		return nil
+	}
+
+	return s.spill()
+}
+
+// spill writes the sorted chunk to a new temporary file.
+func (s *selectorDBSorter) spill() error {
+	f, err := os.CreateTemp(s.dir, s.prefix)
+	if err != nil {
+		return err
+	}
+	s.files = append(s.files, f)
+
+	sortSelectorDBEntries(s.chunk)
+
+	w := bufio.NewWriterSize(f, 64*1024)
+	var scratch [binary.MaxVarintLen64]byte
+	for _, e := range s.chunk {
+		w.Write(e.selector[:])
+
+		n := binary.PutUvarint(scratch[:], uint64(len(e.signature)))
+		w.Write(scratch[:n])
+
+		if _, err := w.WriteString(e.signature); err != nil {
+			return err
+		}
+	}
+
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	s.chunk = s.chunk[:0]
+
+	return nil
+}
+
+// seqs returns the sorted sequences of the chunks, the last one is kept in memory.
+func (s *selectorDBSorter) seqs() ([]selectorDBSeq, error) {
+	sortSelectorDBEntries(s.chunk)
+	seqs := []selectorDBSeq{sliceSelectorDBSeq(s.chunk)}
+
+	for _, f := range s.files {
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+
+		seqs = append(seqs, readerSelectorDBSeq(bufio.NewReaderSize(f, 64*1024)))
+	}
+
+	return seqs, nil
+}
+
+// close removes the chunk files.
+func (s *selectorDBSorter) close() {
+	for _, f := range s.files {
+		f.Close()
+		os.Remove(f.Name())
+	}
+
+	s.chunk, s.files = nil, nil
+}
+
+func sortSelectorDBEntries(entries []selectorDBEntry) {
+	sort.Slice(entries, func(i, j int) bool {
+		return lessSelectorDBEntry(entries[i], entries[j])
+	})
+}
+
+// selectorDBSeq returns the next entry of a sorted sequence, or io.EOF after the last one.
+type selectorDBSeq func() (selectorDBEntry, error)
+
+func sliceSelectorDBSeq(entries []selectorDBEntry) selectorDBSeq {
+	return func() (selectorDBEntry, error) {
+		if len(entries) == 0 {
+			return selectorDBEntry{}, io.EOF
+		}
+
+		e := entries[0]
+		entries = entries[1:]
+
+		return e, nil
+	}
+}
+
+func readerSelectorDBSeq(r *bufio.Reader) selectorDBSeq {
+	return func() (selectorDBEntry, error) {
+		var e selectorDBEntry
+		if _, err := io.ReadFull(r, e.selector[:]); err != nil {
+			return e, err
+		}
+
+		sig, err := readSelectorDBRecord(r)
+		if errors.Is(err, io.EOF) {
+			err = io.ErrUnexpectedEOF
+		}
+		e.signature = sig
+
+		return e, err
+	}
+}
+
+type selectorDBCursor struct {
+	head selectorDBEntry
+	seq  selectorDBSeq
+}
+
+// selectorDBMerger merges sorted sequences of entries, it's a heap of the sequences by their next entries.
+type selectorDBMerger []selectorDBCursor
+
+func mergeSelectorDBSeqs(seqs []selectorDBSeq) (*selectorDBMerger, error) {
+	m := &selectorDBMerger{}
+	for _, seq := range seqs {
+		e, err := seq()
+		if errors.Is(err, io.EOF) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		*m = append(*m, selectorDBCursor{head: e, seq: seq})
+	}
+	heap.Init(m)
+
+	return m, nil
+}
+
+// peek returns the least entry of the sequences, the merger must not be empty.
+func (m *selectorDBMerger) peek() selectorDBEntry {
+	return (*m)[0].head
+}
+
+// next advances past the entry returned by peek.
+func (m *selectorDBMerger) next() error {
+	e, err := (*m)[0].seq()
+	if errors.Is(err, io.EOF) {
+		heap.Pop(m)
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	(*m)[0].head = e
+	heap.Fix(m, 0)
+
+	return nil
+}
+
+func (m selectorDBMerger) Len() int { return len(m) }
+
+func (m selectorDBMerger) Less(i, j int) bool { return lessSelectorDBEntry(m[i].head, m[j].head) }
+
+func (m selectorDBMerger) Swap(i, j int) { m[i], m[j] = m[j], m[i] }
+
+func (m *selectorDBMerger) Push(x interface{}) { *m = append(*m, x.(selectorDBCursor)) }
+
+func (m *selectorDBMerger) Pop() interface{} {
+	old := *m
+	c := old[len(old)-1]
+	*m = old[:len(old)-1]
+
+	return c
+}
@@ -0,0 +1,247 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestSelectorDB(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "selectors.db")
+
+	db, err := OpenSelectorDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := db.ImportFourByteJSON(strings.NewReader(`{
+		"count": 4,
+		"next": null,
+		"results": [
+			{"id": 3, "text_signature": "func_2093253501(bytes)", "hex_signature": "0xa9059cbb"},
+			{"id": 1, "text_signature": "transfer(address,uint256)", "hex_signature": "0xa9059cbb"},
+			{"id": 2, "text_signature": "balanceOf(address)", "hex_signature": "0x70a08231"},
+			{"id": 4, "text_signature": "bogus(address)", "hex_signature": "0x12345678"}
+		]
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("ImportFourByteJSON() = %d, want 3", n)
+	}
+
+	n, err = db.ImportCSV(strings.NewReader(strings.Join([]string{
+		"selector,signature",
+		"# comment",
+		"0x18160ddd,totalSupply()",
+		"a9059cbb,transfer(address,uint256)",
+		"approve(address,uint256)",
+		"",
+	}, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 {
+		t.Errorf("ImportCSV() = %d, want 2", n)
+	}
+
+	n, err = db.Add("allowance(address,address)", "totalSupply()", "not a signature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 1 {
+		t.Errorf("Add() = %d, want 1", n)
+	}
+
+	check := func(t *testing.T, db *SelectorDB) {
+		t.Helper()
+
+		if got := db.Len(); got != 6 {
+			t.Errorf("Len() = %d, want 6", got)
+		}
+
+		tests := []struct {
+			selector []byte
+			want     []string
+		}{
+			{[]byte{0xa9, 0x05, 0x9c, 0xbb}, []string{"func_2093253501(bytes)", "transfer(address,uint256)"}},
+			{[]byte{0x18, 0x16, 0x0d, 0xdd}, []string{"totalSupply()"}},
+			{[]byte{0xdd, 0x62, 0xed, 0x3e}, []string{"allowance(address,address)"}},
+			{[]byte{0x12, 0x34, 0x56, 0x78}, nil},
+		}
+		for _, tt := range tests {
+			got, err := db.Lookup(ctx, tt.selector)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup(%x) = %v, want %v", tt.selector, got, tt.want)
+			}
+		}
+
+		got, err := db.LookupName(ctx, "transfer")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := []string{"transfer(address,uint256)"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("LookupName() = %v, want %v", got, want)
+		}
+	}
+
+	check(t, db)
+
+	// journal is replayed on reopen
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if db, err = OpenSelectorDB(path); err != nil {
+		t.Fatal(err)
+	}
+	check(t, db)
+
+	if err := db.Compact(); err != nil {
+		t.Fatal(err)
+	}
+	check(t, db)
+
+	if err := db.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if db, err = OpenSelectorDB(path); err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+	check(t, db)
+
+	got, err := NewDisassembler(WithSignatureResolver(db)).Disassemble("63dd62ed3e00")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := got.FoundSignatures["allowance(address,address)"]; !ok {
+		t.Errorf("expected allowance(address,address) in found signatures, got %v", got.FoundSignatures)
+	}
+}
+
+func TestSelectorDB_FailedRewrite(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "selectors.db")
+
+	db, err := OpenSelectorDB(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Add("transfer(address,uint256)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// a directory in place of the database file can neither be replaced nor reopened
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(filepath.Join(path, "dir"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := db.Compact(); !errors.Is(err, ErrSelectorDBUnusable) {
+		t.Fatalf("Compact() error = %v, want %v", err, ErrSelectorDBUnusable)
+	}
+
+	if _, err := db.Lookup(context.Background(), []byte{0xa9, 0x05, 0x9c, 0xbb}); !errors.Is(err, ErrSelectorDBUnusable) {
+		t.Errorf("Lookup() error = %v, want %v", err, ErrSelectorDBUnusable)
+	}
+
+	if _, err := db.Add("approve(address,uint256)"); !errors.Is(err, ErrSelectorDBUnusable) {
+		t.Errorf("Add() error = %v, want %v", err, ErrSelectorDBUnusable)
+	}
+}
+
+func TestSelectorDB_ImportChunks(t *testing.T) {
+	defer func(n int) { selectorDBSortChunk = n }(selectorDBSortChunk)
+	selectorDBSortChunk = 2
+
+	dir := t.TempDir()
+	db, err := OpenSelectorDB(filepath.Join(dir, "selectors.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	if _, err := db.Add("approve(address,uint256)"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := db.Import("totalSupply()", "balanceOf(address)"); err != nil {
+		t.Fatal(err)
+	}
+
+	// 7 valid records in 4 chunks, with duplicates across the chunks, the journal and the database
+	n, err := db.ImportCSV(strings.NewReader(strings.Join([]string{
+		"transfer(address,uint256)",
+		"totalSupply()",
+		"allowance(address,address)",
+		"approve(address,uint256)",
+		"transfer(address,uint256)",
+		"name()",
+		"0x70a08231,balanceOf(address)",
+	}, "\n")))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("ImportCSV() = %d, want 3", n)
+	}
+	if got := db.Len(); got != 6 {
+		t.Errorf("Len() = %d, want 6", got)
+	}
+
+	var got []string
+	err = db.iterateMain(func(e selectorDBEntry) error {
+		if e.selector != selectorOf(e.signature) {
+			t.Errorf("entry %x %s has a wrong selector", e.selector, e.signature)
+		}
+		got = append(got, e.signature)
+
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"name()", "approve(address,uint256)", "totalSupply()", "balanceOf(address)",
+		"transfer(address,uint256)", "allowance(address,address)",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("database entries = %v, want %v", got, want)
+	}
+
+	files, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, f := range files {
+		if strings.Contains(f.Name(), ".import-") {
+			t.Errorf("chunk file %s was not removed", f.Name())
+		}
+	}
+}
@@ -0,0 +1,570 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/binary"
+	"fmt"
+	"sort"
+
+	"github.com/holiman/uint256"
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Opcodes with constant operands evaluated by the stack analysis.
+const (
+	opADD evmops.Opcode = 0x01
+	opMUL evmops.Opcode = 0x02
+	opSUB evmops.Opcode = 0x03
+	opMOD evmops.Opcode = 0x06
+	opLT  evmops.Opcode = 0x10
+	opGT  evmops.Opcode = 0x11
+	opAND evmops.Opcode = 0x16
+	opOR  evmops.Opcode = 0x17
+	opNOT evmops.Opcode = 0x19
+	opSHL evmops.Opcode = 0x1b
+	opPC  evmops.Opcode = 0x58
+)
+
+const (
+	// MaxStackHeight is the maximum number of items on the EVM stack.
+	MaxStackHeight = 1024
+
+	// maxStackValues is the maximum number of possible constants tracked per stack item,
+	// items with more possible values are unknown.
+	maxStackValues = 64
+
+	// maxStackContexts is the maximum number of the entry states analysed per block
+	// with distinct jump destinations on the stack. The states over the limit are joined by height.
+	maxStackContexts = 128
+
+	// maxStackHeights is the maximum number of distinct entry stack heights analysed per block.
+	maxStackHeights = 32
+)
+
+// ResolvedJump is a jump with a dynamic destination resolved by the stack analysis.
+type ResolvedJump struct {
+	// PC is the program counter of JUMP or JUMPI
+	PC uint64
+
+	// Targets are the possible destinations that are JUMPDEST, sorted
+	Targets []uint64
+}
+
+// StackAnalysis is the result of the abstract interpretation of the stack effects of the code.
+type StackAnalysis struct {
+	// EntryHeights are the possible stack heights at the entry of the JUMPDEST blocks, sorted,
+	// keyed by the program counter of the JUMPDEST. Blocks not reached by the analysis are absent.
+	EntryHeights map[uint64][]int
+
+	// ResolvedJumps are the jumps with destinations not pushed right before them,
+	// resolved by the constant propagation, ordered by program counter.
+	// The targets may be partial if the analysis is Incomplete.
+	ResolvedJumps []ResolvedJump
+
+	// Diagnostics are the stack underflows and overflows, ordered by program counter.
+	Diagnostics []Diagnostic
+
+	// Incomplete is true if some blocks were entered with more distinct stack heights than analysed,
+	// e.g. in loops growing the stack.
+	Incomplete bool
+}
+
+// stackValue is an abstract stack item: the sorted set of its possible constant values, nil if unknown.
+type stackValue []uint256.Int
+
+// stackState is an abstract stack, the top of the stack is the last item.
+type stackState []stackValue
+
+// stackKey identifies an entry state of a block. The context is the encoded jump destinations
+// on the stack, so the internal functions are analysed separately for every chain of callers,
+// and the return addresses of different callers are not mixed.
+type stackKey struct {
+	block   int
+	height  int
+	context string
+}
+
+// stackEntry is an entry state of a block.
+type stackEntry struct {
+	st stackState
+
+	// joined is true if the state is joined from different contexts,
+	// so it may describe paths that never execute
+	joined bool
+}
+
+type stackAnalyzer struct {
+	g *CFG
+
+	// states are the entry states of the blocks by block ID
+	states map[int]map[stackKey]*stackEntry
+	queue  []stackKey
+	queued map[stackKey]bool
+
+	// targets are the destinations of the block terminators, unknown marks blocks with
+	// a destination that is not a constant in some state
+	targets map[int]map[uint64]bool
+	unknown map[int]bool
+
+	issues map[uint64]Diagnostic
+	res    *StackAnalysis
+}
+
+// AnalyzeStack interprets the stack effects of the code from the contract entry with the empty stack.
+// It tracks the stack height, reports underflows and overflows, and propagates the pushed constants
+// through DUP, SWAP and simple arithmetic, so the jumps to destinations pushed earlier,
+// e.g. returns from internal functions and jump tables, are resolved.
+//
+// The edges to the resolved destinations are added to the graph, the resolved blocks are no longer
+// marked with UnresolvedJump unless the analysis is incomplete, and the reachability is recomputed. Memory and storage are not modelled,
+// so the destinations loaded from them stay unresolved. Underflows and overflows are only reported
+// on the paths the analysis is precise for.
+func AnalyzeStack(g *CFG) *StackAnalysis {
+	a := &stackAnalyzer{
+		g:       g,
+		states:  map[int]map[stackKey]*stackEntry{},
+		queued:  map[stackKey]bool{},
+		targets: map[int]map[uint64]bool{},
+		unknown: map[int]bool{},
+		issues:  map[uint64]Diagnostic{},
+		res: &StackAnalysis{
+			EntryHeights: map[uint64][]int{},
+		},
+	}
+
+	if len(g.Blocks) > 0 {
+		a.propagate(0, stackState{}, false)
+	}
+
+	for len(a.queue) > 0 {
+		key := a.queue[0]
+		a.queue = a.queue[1:]
+		a.queued[key] = false
+
+		a.run(g.Blocks[key.block], a.states[key.block][key])
+	}
+
+	a.finish()
+
+	return a.res
+}
+
+// run interprets the block entered with the state and propagates the exit state to the successors.
+func (a *stackAnalyzer) run(b *Block, entry *stackEntry) {
+	st := make(stackState, len(entry.st))
+	copy(st, entry.st)
+
+	for _, line := range b.Lines {
+		inst := line.Inst
+		if len(st) < inst.InCount {
+			a.report(entry, line.ProgramCounter, DiagnosticStackUnderflow,
+				fmt.Sprintf("%s needs %d stack items, got %d", inst.Mnemonic, inst.InCount, len(st)))
+			return
+		}
+
+		if inst.Code == opJUMP || inst.Code == opJUMPI {
+			dest := st[len(st)-1]
+			st = st[:len(st)-inst.InCount]
+
+			a.jump(b, dest, st, entry.joined, inst.Code == opJUMPI)
+			if inst.Code == opJUMPI {
+				a.propagateNext(b, st, entry.joined)
+			}
+			return
+		}
+
+		if halts(inst) {
+			return
+		}
+
+		st = transfer(st, line)
+		if len(st) > MaxStackHeight {
+			a.report(entry, line.ProgramCounter, DiagnosticStackOverflow,
+				fmt.Sprintf("%s exceeds the stack limit of %d items", inst.Mnemonic, MaxStackHeight))
+			return
+		}
+	}
+
+	a.propagateNext(b, st, entry.joined)
+}
+
+// jump records the destinations of the block terminator and propagates the state to them.
+func (a *stackAnalyzer) jump(b *Block, dest stackValue, st stackState, joined, conditional bool) {
+	if dest == nil {
+		a.unknown[b.ID] = true
+		return
+	}
+
+	if a.targets[b.ID] == nil {
+		a.targets[b.ID] = map[uint64]bool{}
+	}
+
+	kind := EdgeJump
+	if conditional {
+		kind = EdgeConditionalJump
+	}
+
+	for i := range dest {
+		if !dest[i].IsUint64() {
+			continue
+		}
+
+		to, ok := a.g.jumpDestBlock(dest[i].Uint64())
+		if !ok {
+			continue
+		}
+
+		a.targets[b.ID][dest[i].Uint64()] = true
+		a.g.addEdge(Edge{From: b.ID, To: to, Kind: kind})
+		a.propagate(to, st, joined)
+	}
+}
+
+// propagateNext propagates the state to the block the execution falls through to.
+func (a *stackAnalyzer) propagateNext(b *Block, st stackState, joined bool) {
+	if b.ID+1 < len(a.g.Blocks) {
+		a.propagate(b.ID+1, st, joined)
+	}
+}
+
+// propagate joins the state into the entry state of the block with the same height and context,
+// and queues the block if the entry state changed.
+func (a *stackAnalyzer) propagate(id int, st stackState, joined bool) {
+	entries := a.states[id]
+	if entries == nil {
+		entries = map[stackKey]*stackEntry{}
+		a.states[id] = entries
+	}
+
+	key := stackKey{block: id, height: len(st), context: a.context(st)}
+	if _, ok := entries[key]; !ok && len(entries) >= maxStackContexts {
+		// too many contexts, fall back to the state joined by height
+		key.context = ""
+		joined = true
+	}
+
+	old, ok := entries[key]
+	switch {
+	case !ok && a.heights(entries, key.height) >= maxStackHeights:
+		a.res.Incomplete = true
+		return
+	case !ok:
+		entries[key] = &stackEntry{st: append(stackState(nil), st...), joined: joined}
+	default:
+		st, changed := joinStates(old.st, st)
+		if !changed && (old.joined || !joined) {
+			return
+		}
+		old.st = st
+		old.joined = old.joined || joined
+	}
+
+	if !a.queued[key] {
+		a.queued[key] = true
+		a.queue = append(a.queue, key)
+	}
+}
+
+// context encodes the positions and values of the stack items that are known jump destinations.
+func (a *stackAnalyzer) context(st stackState) string {
+	var buf []byte
+	for i, v := range st {
+		if len(v) != 1 || !v[0].IsUint64() {
+			continue
+		}
+
+		if _, ok := a.g.jumpDestBlock(v[0].Uint64()); ok {
+			buf = binary.AppendUvarint(buf, uint64(i))
+			buf = binary.AppendUvarint(buf, v[0].Uint64())
+		}
+	}
+
+	return string(buf)
+}
+
+// heights returns the number of distinct stack heights of the entries if the height is added.
+func (a *stackAnalyzer) heights(entries map[stackKey]*stackEntry, height int) int {
+	seen := map[int]bool{height: true}
+	for key := range entries {
+		seen[key.height] = true
+	}
+
+	return len(seen)
+}
+
+func (a *stackAnalyzer) report(entry *stackEntry, pc uint64, kind DiagnosticKind, reason string) {
+	if entry.joined {
+		return
+	}
+
+	if _, ok := a.issues[pc]; !ok {
+		a.issues[pc] = Diagnostic{PC: pc, Kind: kind, Reason: reason}
+	}
+}
+
+// finish fills the analysis results and updates the graph.
+func (a *stackAnalyzer) finish() {
+	for id, entries := range a.states {
+		b := a.g.Blocks[id]
+		if !b.IsJumpDest() {
+			continue
+		}
+
+		seen := map[int]bool{}
+		var heights []int
+		for key := range entries {
+			if !seen[key.height] {
+				seen[key.height] = true
+				heights = append(heights, key.height)
+			}
+		}
+		sort.Ints(heights)
+		a.res.EntryHeights[b.Start] = heights
+	}
+
+	for _, b := range a.g.Blocks {
+		if !b.UnresolvedJump || a.states[b.ID] == nil || a.unknown[b.ID] {
+			continue
+		}
+
+		// no targets are recorded if every path ends before the terminator
+		if _, ok := a.targets[b.ID]; !ok {
+			continue
+		}
+
+		// some entry states weren't analysed, so the jump may have other targets
+		if !a.res.Incomplete {
+			b.UnresolvedJump = false
+		}
+
+		jump := ResolvedJump{PC: b.End}
+		for dest := range a.targets[b.ID] {
+			jump.Targets = append(jump.Targets, dest)
+		}
+		sort.Slice(jump.Targets, func(i, j int) bool {
+			return jump.Targets[i] < jump.Targets[j]
+		})
+		a.res.ResolvedJumps = append(a.res.ResolvedJumps, jump)
+	}
+
+	sort.Slice(a.res.ResolvedJumps, func(i, j int) bool {
+		return a.res.ResolvedJumps[i].PC < a.res.ResolvedJumps[j].PC
+	})
+
+	for _, d := range a.issues {
+		a.res.Diagnostics = append(a.res.Diagnostics, d)
+	}
+	sort.Slice(a.res.Diagnostics, func(i, j int) bool {
+		return a.res.Diagnostics[i].PC < a.res.Diagnostics[j].PC
+	})
+
+	a.g.index()
+	a.g.computeReachability()
+}
+
+// transfer applies the stack effect of the instruction to the state, which has enough items for it.
+func transfer(st stackState, line evmops.Line) stackState {
+	op := line.Inst.Code
+
+	switch {
+	case op == opPUSH0:
+		return append(st, stackValue{uint256.Int{}})
+
+	case op >= opPUSH1 && op <= opPUSH32:
+		var v uint256.Int
+		if len(line.Args) == 1 {
+			arg := []byte(line.Args[0])
+			v.SetBytes(arg)
+			// the EVM pads the truncated immediate with zeros on the right
+			v.Lsh(&v, uint(8*(pushSize(op)-len(arg))))
+		}
+		return append(st, stackValue{v})
+
+	case op >= opDUP1 && op <= opDUP16:
+		return append(st, st[len(st)-1-int(op-opDUP1)])
+
+	case op >= opSWAP1 && op <= opSWAP16:
+		top := len(st) - 1
+		n := int(op-opSWAP1) + 1
+		st[top], st[top-n] = st[top-n], st[top]
+		return st
+
+	case op == opPC:
+		var v uint256.Int
+		v.SetUint64(line.ProgramCounter)
+		return append(st, stackValue{v})
+
+	case op == opNOT || op == opISZERO:
+		x := st[len(st)-1]
+		return append(st[:len(st)-1], evalUnary(op, x))
+
+	case isEvaluated(op):
+		x, y := st[len(st)-1], st[len(st)-2]
+		return append(st[:len(st)-2], evalBinary(op, x, y))
+	}
+
+	st = st[:len(st)-line.Inst.InCount]
+	for i := 0; i < line.Inst.OutCount; i++ {
+		st = append(st, nil)
+	}
+
+	return st
+}
+
+func isEvaluated(op evmops.Opcode) bool {
+	switch op {
+	case opADD, opMUL, opSUB, opDIV, opMOD, opLT, opGT, opEQ, opAND, opOR, opXOR, opSHL, opSHR:
+		return true
+	}
+
+	return false
+}
+
+func evalUnary(op evmops.Opcode, x stackValue) stackValue {
+	if x == nil {
+		return nil
+	}
+
+	out := make(stackValue, len(x))
+	for i := range x {
+		switch op {
+		case opNOT:
+			out[i].Not(&x[i])
+		case opISZERO:
+			if x[i].IsZero() {
+				out[i].SetOne()
+			}
+		}
+	}
+
+	return normalizeValue(out)
+}
+
+// evalBinary evaluates the operation for every pair of the possible values,
+// x is the top of the stack and y is the next item.
+func evalBinary(op evmops.Opcode, x, y stackValue) stackValue {
+	if x == nil || y == nil {
+		return nil
+	}
+
+	out := make(stackValue, 0, len(x)*len(y))
+	for i := range x {
+		for j := range y {
+			var z uint256.Int
+			switch op {
+			case opADD:
+				z.Add(&x[i], &y[j])
+			case opMUL:
+				z.Mul(&x[i], &y[j])
+			case opSUB:
+				z.Sub(&x[i], &y[j])
+			case opDIV:
+				z.Div(&x[i], &y[j])
+			case opMOD:
+				z.Mod(&x[i], &y[j])
+			case opLT:
+				if x[i].Lt(&y[j]) {
+					z.SetOne()
+				}
+			case opGT:
+				if x[i].Gt(&y[j]) {
+					z.SetOne()
+				}
+			case opEQ:
+				if x[i].Eq(&y[j]) {
+					z.SetOne()
+				}
+			case opAND:
+				z.And(&x[i], &y[j])
+			case opOR:
+				z.Or(&x[i], &y[j])
+			case opXOR:
+				z.Xor(&x[i], &y[j])
+			case opSHL:
+				if x[i].LtUint64(256) {
+					z.Lsh(&y[j], uint(x[i].Uint64()))
+				}
+			case opSHR:
+				if x[i].LtUint64(256) {
+					z.Rsh(&y[j], uint(x[i].Uint64()))
+				}
+			}
+			out = append(out, z)
+		}
+	}
+
+	return normalizeValue(out)
+}
+
+// normalizeValue sorts and deduplicates the possible values, more than maxStackValues of them are unknown.
+func normalizeValue(v stackValue) stackValue {
+	sort.Slice(v, func(i, j int) bool {
+		return v[i].Lt(&v[j])
+	})
+
+	out := v[:0]
+	for i := range v {
+		if i == 0 || !v[i].Eq(&v[i-1]) {
+			out = append(out, v[i])
+		}
+	}
+
+	if len(out) > maxStackValues {
+		return nil
+	}
+
+	return out
+}
+
+// joinStates joins the states of the same height and returns true if the result differs from old.
+func joinStates(old, st stackState) (stackState, bool) {
+	joined := make(stackState, len(old))
+	changed := false
+	for i := range old {
+		joined[i] = joinValues(old[i], st[i])
+		if !equalValues(joined[i], old[i]) {
+			changed = true
+		}
+	}
+
+	return joined, changed
+}
+
+func joinValues(a, b stackValue) stackValue {
+	if a == nil || b == nil {
+		return nil
+	}
+
+	v := make(stackValue, 0, len(a)+len(b))
+	v = append(v, a...)
+	v = append(v, b...)
+
+	return normalizeValue(v)
+}
+
+func equalValues(a, b stackValue) bool {
+	if (a == nil) != (b == nil) || len(a) != len(b) {
+		return false
+	}
+
+	for i := range a {
+		if !a[i].Eq(&b[i]) {
+			return false
+		}
+	}
+
+	return true
+}
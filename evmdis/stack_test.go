@@ -0,0 +1,243 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmasm"
+)
+
+func TestAnalyzeStack(t *testing.T) {
+	tests := []struct {
+		name            string
+		src             string
+		wantResolved    []ResolvedJump
+		wantHeights     map[uint64][]int
+		wantDiagnostics []Diagnostic
+		wantUnresolved  []uint64
+	}{
+		{
+			name: "internal function called twice",
+			src: `
+				PUSH @ret1
+				PUSH1 0x01
+				PUSH @fn
+				JUMP
+			ret1:
+				JUMPDEST
+				POP
+				PUSH @ret2
+				PUSH1 0x02
+				PUSH @fn
+				JUMP
+			ret2:
+				JUMPDEST
+				POP
+				STOP
+			fn:
+				JUMPDEST
+				SWAP1
+				JUMP
+			`,
+			wantResolved: []ResolvedJump{{PC: 21, Targets: []uint64{7, 16}}},
+			wantHeights:  map[uint64][]int{7: {1}, 16: {1}, 19: {2}},
+		},
+		{
+			name: "destination chosen by branch",
+			src: `
+				PUSH1 0x00
+				CALLDATALOAD
+				PUSH @other
+				JUMPI
+				PUSH @a
+				PUSH @join
+				JUMP
+			other:
+				JUMPDEST
+				PUSH @b
+			join:
+				JUMPDEST
+				JUMP
+			a:
+				JUMPDEST
+				STOP
+			b:
+				JUMPDEST
+				STOP
+			`,
+			wantResolved: []ResolvedJump{{PC: 15, Targets: []uint64{16, 18}}},
+			wantHeights:  map[uint64][]int{11: {0}, 14: {1}, 16: {0}, 18: {0}},
+		},
+		{
+			name: "computed destination",
+			src: `
+				PC
+				PUSH1 0x05
+				ADD
+				JUMP
+				JUMPDEST
+				STOP
+			`,
+			wantResolved: []ResolvedJump{{PC: 4, Targets: []uint64{5}}},
+			wantHeights:  map[uint64][]int{5: {0}},
+		},
+		{
+			name: "destination from calldata",
+			src: `
+				PUSH1 0x00
+				CALLDATALOAD
+				JUMP
+				JUMPDEST
+				STOP
+			`,
+			wantHeights:    map[uint64][]int{},
+			wantUnresolved: []uint64{3},
+		},
+		{
+			name: "underflow",
+			src: `
+				PUSH1 0x01
+				SWAP2
+				STOP
+			`,
+			wantHeights: map[uint64][]int{},
+			wantDiagnostics: []Diagnostic{
+				{PC: 2, Kind: DiagnosticStackUnderflow, Reason: "SWAP2 needs 3 stack items, got 1"},
+			},
+		},
+		{
+			name:        "overflow",
+			src:         strings.Repeat("PUSH1 0x00\n", MaxStackHeight+1),
+			wantHeights: map[uint64][]int{},
+			wantDiagnostics: []Diagnostic{
+				{PC: 2 * MaxStackHeight, Kind: DiagnosticStackOverflow, Reason: "PUSH1 exceeds the stack limit of 1024 items"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := evmasm.Assemble(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			g := BuildCFG(disassembleOffline(t, hex.EncodeToString(code)))
+			got := AnalyzeStack(g)
+
+			if !reflect.DeepEqual(got.ResolvedJumps, tt.wantResolved) {
+				t.Errorf("AnalyzeStack() ResolvedJumps = %v, want %v", got.ResolvedJumps, tt.wantResolved)
+			}
+			if !reflect.DeepEqual(got.EntryHeights, tt.wantHeights) {
+				t.Errorf("AnalyzeStack() EntryHeights = %v, want %v", got.EntryHeights, tt.wantHeights)
+			}
+			if !reflect.DeepEqual(got.Diagnostics, tt.wantDiagnostics) {
+				t.Errorf("AnalyzeStack() Diagnostics = %v, want %v", got.Diagnostics, tt.wantDiagnostics)
+			}
+
+			var unresolved []uint64
+			for _, b := range g.Blocks {
+				if b.UnresolvedJump {
+					unresolved = append(unresolved, b.End)
+				}
+			}
+			if !reflect.DeepEqual(unresolved, tt.wantUnresolved) {
+				t.Errorf("unresolved jumps = %v, want %v", unresolved, tt.wantUnresolved)
+			}
+		})
+	}
+}
+
+func TestAnalyzeStack_incomplete(t *testing.T) {
+	// the loop grows the stack, so its entry heights aren't all analysed
+	code, err := evmasm.Assemble(`
+		loop:
+			JUMPDEST
+			PUSH1 0x00
+			PUSH1 0x00
+			CALLDATALOAD
+			PUSH @loop
+			JUMPI
+			PUSH @end
+			DUP1
+			JUMP
+		end:
+			JUMPDEST
+			STOP
+	`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	g := BuildCFG(disassembleOffline(t, hex.EncodeToString(code)))
+	got := AnalyzeStack(g)
+	if !got.Incomplete {
+		t.Fatal("AnalyzeStack() Incomplete = false")
+	}
+
+	want := []ResolvedJump{{PC: 7, Targets: []uint64{0}}, {PC: 11, Targets: []uint64{12}}}
+	if !reflect.DeepEqual(got.ResolvedJumps, want) {
+		t.Errorf("AnalyzeStack() ResolvedJumps = %v, want %v", got.ResolvedJumps, want)
+	}
+
+	for _, jump := range want {
+		for _, b := range g.Blocks {
+			if b.End == jump.PC && !b.UnresolvedJump {
+				t.Errorf("the jump at %d isn't marked with UnresolvedJump", jump.PC)
+			}
+		}
+	}
+}
+
+// TestAnalyzeStack_contracts checks that all the reachable jumps of the compiled contracts are resolved.
+func TestAnalyzeStack_contracts(t *testing.T) {
+	tests := []struct {
+		name string
+		code string
+	}{
+		{"usdt", usdtCreationCode},
+		{"uniswap", uniswapRuntimeCode},
+		{"ens", ensRuntimeCode},
+		{"goerli_test_token", goerliTestTokenCreationCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := BuildCFG(disassembleOffline(t, tt.code))
+			got := AnalyzeStack(g)
+
+			if got.Incomplete {
+				t.Error("AnalyzeStack() is incomplete")
+			}
+			if len(got.Diagnostics) != 0 {
+				t.Errorf("AnalyzeStack() Diagnostics = %v, want none", got.Diagnostics)
+			}
+
+			for _, b := range g.Blocks {
+				if b.Reachable && b.UnresolvedJump {
+					t.Errorf("jump at pc=%d is not resolved", b.End)
+				}
+			}
+
+			edges, succs, preds := append([]Edge(nil), g.Edges...), g.succs, g.preds
+			g.index()
+			if !reflect.DeepEqual(g.Edges, edges) || !reflect.DeepEqual(g.succs, succs) || !reflect.DeepEqual(g.preds, preds) {
+				t.Error("AnalyzeStack() left the graph edges unsorted")
+			}
+		})
+	}
+}
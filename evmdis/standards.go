@@ -0,0 +1,320 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"sort"
+	"sync"
+
+	"github.com/kirillDanshin/evmtools"
+)
+
+// Standard is a contract interface standard, described by the canonical signatures of its functions.
+type Standard struct {
+	// Name is the unique name of the standard, e.g. "ERC-20"
+	Name string
+
+	// Required are the functions every implementation has
+	Required []string
+
+	// Optional are the functions of optional extensions, they don't affect the detection
+	Optional []string
+}
+
+// StandardMatch is a standard detected in the contract.
+type StandardMatch struct {
+	Standard Standard
+
+	// Confidence is the share of the required functions found in the contract, from 0 to 1.
+	Confidence float64
+
+	// Missing are the required functions not found in the contract
+	Missing []string
+
+	// MissingSelectors are the 0x-prefixed selectors of the Missing functions
+	MissingSelectors []string
+
+	// Optional are the optional functions found in the contract
+	Optional []string
+}
+
+// Complete returns true if all the required functions of the standard are found.
+func (m *StandardMatch) Complete() bool {
+	return len(m.Missing) == 0
+}
+
+var (
+	standardsMu sync.RWMutex
+	standards   = map[string]Standard{}
+)
+
+// RegisterStandard adds the standard to the registry used by DetectStandards,
+// replacing the registered standard with the same name.
+func RegisterStandard(s Standard) {
+	standardsMu.Lock()
+	defer standardsMu.Unlock()
+
+	standards[s.Name] = s
+}
+
+// LookupStandard returns the registered standard with the given name.
+func LookupStandard(name string) (Standard, bool) {
+	standardsMu.RLock()
+	defer standardsMu.RUnlock()
+
+	s, ok := standards[name]
+
+	return s, ok
+}
+
+// Standards returns the registered standards sorted by name.
+func Standards() []Standard {
+	standardsMu.RLock()
+	defer standardsMu.RUnlock()
+
+	list := make([]Standard, 0, len(standards))
+	for _, s := range standards {
+		list = append(list, s)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+// DetectStandards matches the contract functions against the registered standards.
+// Standards without any of the required functions found are omitted,
+// the rest are sorted by confidence, highest first, and by name.
+// Functions are looked up as in Results.Implements.
+func DetectStandards(r *Results) []StandardMatch {
+	var matches []StandardMatch
+	for _, s := range Standards() {
+		m := StandardMatch{Standard: s}
+
+		found := 0
+		for _, sig := range s.Required {
+			if r.hasFunction(sig) {
+				found++
+				continue
+			}
+
+			m.Missing = append(m.Missing, sig)
+			m.MissingSelectors = append(m.MissingSelectors, "0x"+hex.EncodeToString(evmtools.MethodID(sig)))
+		}
+
+		if found == 0 {
+			continue
+		}
+		m.Confidence = float64(found) / float64(len(s.Required))
+
+		for _, sig := range s.Optional {
+			if r.hasFunction(sig) {
+				m.Optional = append(m.Optional, sig)
+			}
+		}
+
+		matches = append(matches, m)
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Confidence > matches[j].Confidence
+	})
+
+	return matches
+}
+
+var (
+	erc20Metadata = []string{
+		"name()",
+		"symbol()",
+		"decimals()",
+	}
+
+	erc165Iface = []string{
+		"supportsInterface(bytes4)",
+	}
+)
+
+func init() {
+	for _, s := range []Standard{
+		{
+			Name:     "ERC-20",
+			Required: ERC20Iface,
+			Optional: erc20Metadata,
+		},
+		{
+			Name:     "ERC-721",
+			Required: ERC721Iface,
+			Optional: []string{
+				"name()",
+				"symbol()",
+				"tokenURI(uint256)",
+				"totalSupply()",
+				"tokenByIndex(uint256)",
+				"tokenOfOwnerByIndex(address,uint256)",
+				"supportsInterface(bytes4)",
+			},
+		},
+		{
+			Name: "ERC-1155",
+			Required: []string{
+				"safeTransferFrom(address,address,uint256,uint256,bytes)",
+				"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+				"balanceOf(address,uint256)",
+				"balanceOfBatch(address[],uint256[])",
+				"setApprovalForAll(address,bool)",
+				"isApprovedForAll(address,address)",
+			},
+			Optional: []string{
+				"uri(uint256)",
+				"supportsInterface(bytes4)",
+			},
+		},
+		{
+			Name: "ERC-777",
+			Required: []string{
+				"name()",
+				"symbol()",
+				"granularity()",
+				"totalSupply()",
+				"balanceOf(address)",
+				"send(address,uint256,bytes)",
+				"burn(uint256,bytes)",
+				"isOperatorFor(address,address)",
+				"authorizeOperator(address)",
+				"revokeOperator(address)",
+				"defaultOperators()",
+				"operatorSend(address,address,uint256,bytes,bytes)",
+				"operatorBurn(address,uint256,bytes,bytes)",
+			},
+			Optional: ERC20Iface,
+		},
+		{
+			Name: "ERC-4626",
+			Required: []string{
+				"asset()",
+				"totalAssets()",
+				"convertToShares(uint256)",
+				"convertToAssets(uint256)",
+				"maxDeposit(address)",
+				"previewDeposit(uint256)",
+				"deposit(uint256,address)",
+				"maxMint(address)",
+				"previewMint(uint256)",
+				"mint(uint256,address)",
+				"maxWithdraw(address)",
+				"previewWithdraw(uint256)",
+				"withdraw(uint256,address,address)",
+				"maxRedeem(address)",
+				"previewRedeem(uint256)",
+				"redeem(uint256,address,address)",
+			},
+			Optional: append(append([]string{}, ERC20Iface...), erc20Metadata...),
+		},
+		{
+			Name: "ERC-2612",
+			Required: []string{
+				"permit(address,address,uint256,uint256,uint8,bytes32,bytes32)",
+				"nonces(address)",
+				"DOMAIN_SEPARATOR()",
+			},
+			Optional: []string{
+				"eip712Domain()",
+			},
+		},
+		{
+			Name: "ERC-2981",
+			Required: []string{
+				"royaltyInfo(uint256,uint256)",
+			},
+			Optional: erc165Iface,
+		},
+		{
+			Name:     "ERC-165",
+			Required: erc165Iface,
+		},
+		{
+			Name: "ERC-1363",
+			Required: []string{
+				"transferAndCall(address,uint256)",
+				"transferAndCall(address,uint256,bytes)",
+				"transferFromAndCall(address,address,uint256)",
+				"transferFromAndCall(address,address,uint256,bytes)",
+				"approveAndCall(address,uint256)",
+				"approveAndCall(address,uint256,bytes)",
+			},
+			Optional: erc165Iface,
+		},
+		{
+			Name: "ERC-3156",
+			Required: []string{
+				"maxFlashLoan(address)",
+				"flashFee(address,uint256)",
+				"flashLoan(address,address,uint256,bytes)",
+			},
+		},
+		{
+			Name: "Ownable",
+			Required: []string{
+				"owner()",
+				"transferOwnership(address)",
+			},
+			Optional: []string{
+				"renounceOwnership()",
+				"pendingOwner()",
+				"acceptOwnership()",
+			},
+		},
+		{
+			Name: "AccessControl",
+			Required: []string{
+				"hasRole(bytes32,address)",
+				"getRoleAdmin(bytes32)",
+				"grantRole(bytes32,address)",
+				"revokeRole(bytes32,address)",
+				"renounceRole(bytes32,address)",
+			},
+			Optional: []string{
+				"DEFAULT_ADMIN_ROLE()",
+				"getRoleMember(bytes32,uint256)",
+				"getRoleMemberCount(bytes32)",
+			},
+		},
+		{
+			Name: "Pausable",
+			Required: []string{
+				"paused()",
+			},
+			Optional: []string{
+				"pause()",
+				"unpause()",
+			},
+		},
+		{
+			Name: "ERC-1822",
+			Required: []string{
+				"proxiableUUID()",
+			},
+			Optional: []string{
+				"upgradeTo(address)",
+				"upgradeToAndCall(address,bytes)",
+			},
+		},
+	} {
+		RegisterStandard(s)
+	}
+}
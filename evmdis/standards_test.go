@@ -0,0 +1,129 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"bytes"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools"
+)
+
+// resultsWithFunctions returns results with a dispatcher table of the given functions.
+func resultsWithFunctions(sigs ...string) *Results {
+	t := &DispatchTable{Kind: DispatcherShift}
+	for i, sig := range sigs {
+		var sel [4]byte
+		copy(sel[:], evmtools.MethodID(sig))
+		t.Entries = append(t.Entries, DispatchEntry{Selector: sel, EntryPC: uint64(i), Signature: sig})
+	}
+	sort.Slice(t.Entries, func(i, j int) bool {
+		return bytes.Compare(t.Entries[i].Selector[:], t.Entries[j].Selector[:]) < 0
+	})
+
+	return &Results{Dispatcher: t}
+}
+
+func TestDetectStandards(t *testing.T) {
+	type match struct {
+		name       string
+		confidence float64
+		missing    []string
+	}
+	tests := []struct {
+		name string
+		r    *Results
+		want []match
+	}{
+		{
+			name: "ownable pausable",
+			r:    resultsWithFunctions("owner()", "transferOwnership(address)", "renounceOwnership()", "paused()"),
+			want: []match{
+				{name: "Ownable", confidence: 1},
+				{name: "Pausable", confidence: 1},
+			},
+		},
+		{
+			name: "partial flash lender",
+			r:    resultsWithFunctions("maxFlashLoan(address)", "flashLoan(address,address,uint256,bytes)"),
+			want: []match{
+				{name: "ERC-3156", confidence: 2.0 / 3, missing: []string{"flashFee(address,uint256)"}},
+			},
+		},
+		{
+			name: "royalties",
+			r:    resultsWithFunctions("royaltyInfo(uint256,uint256)", "supportsInterface(bytes4)"),
+			want: []match{
+				{name: "ERC-165", confidence: 1},
+				{name: "ERC-2981", confidence: 1},
+			},
+		},
+		{
+			name: "none",
+			r:    resultsWithFunctions("foo()"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []match
+			for _, m := range DetectStandards(tt.r) {
+				got = append(got, match{name: m.Standard.Name, confidence: m.Confidence, missing: m.Missing})
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("DetectStandards() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetectStandards_ERC20(t *testing.T) {
+	r := disassembleOffline(t, uniswapRuntimeCode)
+
+	matches := DetectStandards(r)
+	if len(matches) == 0 || matches[0].Standard.Name != "ERC-20" || !matches[0].Complete() {
+		t.Fatalf("expected complete ERC-20 match first, got %+v", matches)
+	}
+
+	if got := matches[0].Optional; !reflect.DeepEqual(got, []string{"name()", "symbol()", "decimals()"}) {
+		t.Errorf("ERC-20 optional functions = %v", got)
+	}
+
+	// the UNI token has permit, but no DOMAIN_SEPARATOR getter
+	for _, m := range matches {
+		if m.Standard.Name == "ERC-2612" && !reflect.DeepEqual(m.MissingSelectors, []string{"0x3644e515"}) {
+			t.Errorf("ERC-2612 missing selectors = %v", m.MissingSelectors)
+		}
+	}
+}
+
+func TestRegisterStandard(t *testing.T) {
+	RegisterStandard(Standard{Name: "test-standard", Required: []string{"foo()"}})
+
+	if _, ok := LookupStandard("test-standard"); !ok {
+		t.Fatal("registered standard not found")
+	}
+
+	matches := DetectStandards(resultsWithFunctions("foo()"))
+	if len(matches) != 1 || matches[0].Standard.Name != "test-standard" {
+		t.Errorf("DetectStandards() = %+v", matches)
+	}
+
+	standardsMu.Lock()
+	delete(standards, "test-standard")
+	standardsMu.Unlock()
+}
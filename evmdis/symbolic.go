@@ -0,0 +1,793 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Opcodes with the side effects tracked by the symbolic execution.
+const (
+	opCALLDATACOPY   evmops.Opcode = 0x37
+	opRETURNDATACOPY evmops.Opcode = 0x3e
+	opEXTCODECOPY    evmops.Opcode = 0x3c
+	opMSTORE8        evmops.Opcode = 0x53
+	opSSTORE         evmops.Opcode = 0x55
+	opMCOPY          evmops.Opcode = 0x5e
+	opCREATE         evmops.Opcode = 0xf0
+	opCALL           evmops.Opcode = 0xf1
+	opCALLCODE       evmops.Opcode = 0xf2
+	opCREATE2        evmops.Opcode = 0xf5
+	opSTATICCALL     evmops.Opcode = 0xfa
+)
+
+const (
+	defaultMaxPaths = 256
+	defaultMaxDepth = 32
+
+	// maxSymbolicSteps is the maximum number of instructions executed per path
+	maxSymbolicSteps = 10000
+
+	// maxHashWords is the maximum number of memory words hashed symbolically
+	maxHashWords = 8
+
+	// maxForgetWords is the maximum number of memory words made unknown one by one,
+	// larger copies make the whole memory unknown
+	maxForgetWords = 1024
+)
+
+// PathEnd is the way an execution path ends.
+type PathEnd uint8
+
+const (
+	// PathStop is STOP or the end of the code.
+	PathStop PathEnd = iota + 1
+
+	// PathReturn is RETURN.
+	PathReturn
+
+	// PathRevert is REVERT.
+	PathRevert
+
+	// PathInvalid is INVALID, an undefined instruction, a stack underflow or a jump to anything but JUMPDEST.
+	PathInvalid
+
+	// PathSelfDestruct is SELFDESTRUCT.
+	PathSelfDestruct
+
+	// PathBounded is a path cut by the depth or step limits, or by a jump to a symbolic destination.
+	PathBounded
+)
+
+func (e PathEnd) String() string {
+	switch e {
+	case PathStop:
+		return "stop"
+	case PathReturn:
+		return "return"
+	case PathRevert:
+		return "revert"
+	case PathInvalid:
+		return "invalid"
+	case PathSelfDestruct:
+		return "selfdestruct"
+	case PathBounded:
+		return "bounded"
+	default:
+		return "invalid end"
+	}
+}
+
+// Constraint is a branch condition of the path.
+type Constraint struct {
+	// PC is the program counter of the JUMPI
+	PC uint64
+
+	Cond Expr
+
+	// Taken is true if the path jumps, i.e. Cond is not zero
+	Taken bool
+}
+
+func (c Constraint) String() string {
+	if c.Taken {
+		return c.Cond.String()
+	}
+
+	return "!" + c.Cond.String()
+}
+
+// StorageAccess is a read or a write of a storage slot.
+type StorageAccess struct {
+	PC   uint64
+	Slot Expr
+
+	// Value is the written value, nil for reads
+	Value Expr
+}
+
+// ExternalCall is a call or a contract creation made by the function.
+type ExternalCall struct {
+	PC uint64
+
+	// Op is CALL, CALLCODE, DELEGATECALL, STATICCALL, CREATE or CREATE2
+	Op evmops.Opcode
+
+	// To is the called address, nil for CREATE and CREATE2
+	To Expr
+
+	// Value is the transferred wei, nil for DELEGATECALL and STATICCALL
+	Value Expr
+
+	// Input is the first word of the call data, nil if it is unknown.
+	// Its first 4 bytes are the selector of the called function.
+	Input Expr
+}
+
+// Selector returns the selector of the called function if it is known.
+func (c ExternalCall) Selector() ([4]byte, bool) {
+	var sel [4]byte
+	if c.Input == nil {
+		return sel, false
+	}
+
+	head, n := knownHead(c.Input)
+	if n < len(sel) {
+		return sel, false
+	}
+	copy(sel[:], head[:])
+
+	return sel, true
+}
+
+// knownHead returns the word and the number of its leading bytes that are known,
+// either as a constant or as or(head, shr(bits, x)) made by the overlapping memory writes.
+func knownHead(e Expr) ([32]byte, int) {
+	if v, ok := Const(e); ok {
+		return v.Bytes32(), 32
+	}
+
+	op, ok := e.(*OpExpr)
+	if !ok || op.Op != opOR {
+		return [32]byte{}, 0
+	}
+
+	for i, arg := range op.Args {
+		head, ok := Const(arg)
+		if !ok {
+			continue
+		}
+
+		shr, ok := op.Args[1-i].(*OpExpr)
+		if !ok || shr.Op != opSHR {
+			continue
+		}
+
+		if bits, ok := Const(shr.Args[0]); ok && bits.IsUint64() && bits.Uint64() <= 256 {
+			return head.Bytes32(), int(bits.Uint64() / 8)
+		}
+	}
+
+	return [32]byte{}, 0
+}
+
+// RevertCondition is a path ending with REVERT or INVALID.
+type RevertCondition struct {
+	// PC is the program counter of the reverting instruction
+	PC uint64
+
+	End PathEnd
+
+	// Constraints are the branch conditions leading to the revert, in execution order
+	Constraints []Constraint
+}
+
+func (r RevertCondition) String() string {
+	conds := make([]string, len(r.Constraints))
+	for i, c := range r.Constraints {
+		conds[i] = c.String()
+	}
+
+	return fmt.Sprintf("pc=%d: %s if %s", r.PC, r.End, strings.Join(conds, " && "))
+}
+
+// FunctionSummary summarises the symbolic execution paths of a function.
+// The accesses and calls are deduplicated by program counter and expression, and ordered by program counter.
+type FunctionSummary struct {
+	Entry DispatchEntry
+
+	Reads  []StorageAccess
+	Writes []StorageAccess
+	Calls  []ExternalCall
+
+	// Reverts are the reverting paths, ordered by program counter
+	Reverts []RevertCondition
+
+	// Paths is the number of the explored paths
+	Paths int
+
+	// Bounded is true if some paths were cut by the limits or the path limit is reached,
+	// so the summary may be incomplete.
+	Bounded bool
+}
+
+// SymbolicOption configures the SymbolicExecutor.
+type SymbolicOption func(*SymbolicExecutor)
+
+// WithMaxPaths limits the number of the paths explored per function.
+func WithMaxPaths(n int) SymbolicOption {
+	return func(e *SymbolicExecutor) {
+		e.maxPaths = n
+	}
+}
+
+// WithMaxDepth limits the number of symbolic branches per path, bounding the loops.
+func WithMaxDepth(n int) SymbolicOption {
+	return func(e *SymbolicExecutor) {
+		e.maxDepth = n
+	}
+}
+
+// SymbolicExecutor enumerates the execution paths of the functions found in the dispatcher,
+// with symbolic calldata, storage, memory and environment.
+//
+// Constant operands are folded, branches with constant conditions are not forked, and the
+// hashes of the known memory words are computed, so the storage slots of mappings are
+// expressed as keccak256(key, slot). There is no solver: both branches of every symbolic
+// condition are explored, even if the path constraints contradict.
+type SymbolicExecutor struct {
+	r        *Results
+	index    map[uint64]int
+	heights  map[uint64][]int
+	maxPaths int
+	maxDepth int
+}
+
+// NewSymbolicExecutor prepares the symbolic execution of the disassembled code.
+func NewSymbolicExecutor(r *Results, opts ...SymbolicOption) *SymbolicExecutor {
+	e := &SymbolicExecutor{
+		r:        r,
+		index:    make(map[uint64]int, len(r.Lines)),
+		heights:  AnalyzeStack(BuildCFG(r)).EntryHeights,
+		maxPaths: defaultMaxPaths,
+		maxDepth: defaultMaxDepth,
+	}
+
+	for _, opt := range opts {
+		opt(e)
+	}
+
+	for i, line := range r.Lines {
+		e.index[line.ProgramCounter] = i
+	}
+
+	return e
+}
+
+// SummarizeAll summarises all the functions of the dispatcher, in the dispatcher order.
+func (e *SymbolicExecutor) SummarizeAll() []FunctionSummary {
+	if e.r.Dispatcher == nil {
+		return nil
+	}
+
+	summaries := make([]FunctionSummary, 0, len(e.r.Dispatcher.Entries))
+	for _, entry := range e.r.Dispatcher.Entries {
+		summaries = append(summaries, e.Summarize(entry))
+	}
+
+	return summaries
+}
+
+// Summarize explores the paths from the function entry.
+// The stack at the entry holds the symbols stack[0], stack[1], ..., bottom first,
+// as many as the stack analysis found at the entry.
+func (e *SymbolicExecutor) Summarize(entry DispatchEntry) FunctionSummary {
+	s := &summarizer{
+		FunctionSummary: FunctionSummary{Entry: entry},
+		seen:            map[string]bool{},
+	}
+
+	start := newSymbolicState(entry.EntryPC)
+	if heights := e.heights[entry.EntryPC]; len(heights) > 0 {
+		for i := 0; i < heights[0]; i++ {
+			start.push(&SymbolExpr{Name: fmt.Sprintf("stack[%d]", i)})
+		}
+	}
+
+	queue := []*symbolicState{start}
+	for len(queue) > 0 {
+		if s.Paths >= e.maxPaths {
+			s.Bounded = true
+			break
+		}
+
+		st := queue[len(queue)-1]
+		queue = queue[:len(queue)-1]
+
+		forks := e.run(st, s)
+		queue = append(queue, forks...)
+	}
+
+	s.finish()
+
+	return s.FunctionSummary
+}
+
+// run executes the path until it ends or forks, and returns the forked paths.
+func (e *SymbolicExecutor) run(st *symbolicState, s *summarizer) []*symbolicState {
+	for ; st.steps < maxSymbolicSteps; st.steps++ {
+		i, ok := e.index[st.pc]
+		if !ok {
+			// running off the end of the code is STOP
+			s.end(st, PathStop)
+			return nil
+		}
+
+		line := e.r.Lines[i]
+		inst := line.Inst
+		if len(st.stack) < inst.InCount {
+			s.end(st, PathInvalid)
+			return nil
+		}
+
+		next := st.pc + 1 + uint64(pushSize(inst.Code))
+		if i+1 < len(e.r.Lines) {
+			next = e.r.Lines[i+1].ProgramCounter
+		}
+
+		switch inst.Code {
+		case opSTOP:
+			s.end(st, PathStop)
+			return nil
+		case opRETURN:
+			s.end(st, PathReturn)
+			return nil
+		case opREVERT:
+			s.end(st, PathRevert)
+			return nil
+		case opSELFDESTRUCT:
+			s.end(st, PathSelfDestruct)
+			return nil
+
+		case opJUMP:
+			dest := st.pop()
+			if !e.jump(st, dest, s) {
+				return nil
+			}
+			continue
+
+		case opJUMPI:
+			dest, cond := st.pop(), st.pop()
+			if c, ok := Const(cond); ok {
+				if c.IsZero() {
+					st.pc = next
+					continue
+				}
+				if !e.jump(st, dest, s) {
+					return nil
+				}
+				continue
+			}
+
+			// a condition decided earlier on the path is not forked again
+			if taken, ok := st.decided(cond); ok {
+				if !taken {
+					st.pc = next
+					continue
+				}
+				if !e.jump(st, dest, s) {
+					return nil
+				}
+				continue
+			}
+
+			if len(st.constraints) >= e.maxDepth {
+				s.end(st, PathBounded)
+				return nil
+			}
+
+			taken := st.clone()
+			taken.steps++
+			taken.constraints = append(taken.constraints, Constraint{PC: st.pc, Cond: cond, Taken: true})
+			st.constraints = append(st.constraints, Constraint{PC: st.pc, Cond: cond})
+			st.pc = next
+			st.steps++
+
+			var forks []*symbolicState
+			if e.jump(taken, dest, s) {
+				forks = append(forks, taken)
+			}
+
+			return append(forks, st)
+		}
+
+		if halts(inst) {
+			s.end(st, PathInvalid)
+			return nil
+		}
+
+		e.step(st, line, s)
+		st.pc = next
+	}
+
+	s.end(st, PathBounded)
+
+	return nil
+}
+
+// jump moves the path to the destination and returns false if the path ends.
+func (e *SymbolicExecutor) jump(st *symbolicState, dest Expr, s *summarizer) bool {
+	v, ok := Const(dest)
+	if !ok {
+		s.end(st, PathBounded)
+		return false
+	}
+
+	i, ok := e.index[v.Uint64()]
+	if !v.IsUint64() || !ok || e.r.Lines[i].Inst.Code != opJUMPDEST {
+		s.end(st, PathInvalid)
+		return false
+	}
+
+	st.pc = v.Uint64()
+
+	return true
+}
+
+// step executes an instruction that doesn't change the control flow.
+func (e *SymbolicExecutor) step(st *symbolicState, line evmops.Line, s *summarizer) {
+	inst := line.Inst
+	op := inst.Code
+
+	switch {
+	case op == opPUSH0 || op >= opPUSH1 && op <= opPUSH32:
+		v := transfer(nil, line)[0]
+		st.push(constExpr(&v[0]))
+		return
+
+	case op >= opDUP1 && op <= opDUP16:
+		st.push(st.stack[len(st.stack)-1-int(op-opDUP1)])
+		return
+
+	case op >= opSWAP1 && op <= opSWAP16:
+		top := len(st.stack) - 1
+		n := int(op-opSWAP1) + 1
+		st.stack[top], st.stack[top-n] = st.stack[top-n], st.stack[top]
+		return
+
+	case op == opPC:
+		st.push(constUint64(line.ProgramCounter))
+		return
+	}
+
+	args := make([]Expr, inst.InCount)
+	for i := range args {
+		args[i] = st.pop()
+	}
+
+	switch op {
+	case opMLOAD:
+		st.push(st.mload(args[0]))
+
+	case opMSTORE:
+		st.mstore(args[0], args[1])
+
+	case opSHA3:
+		st.push(st.sha3(args[0], args[1]))
+
+	case opSLOAD:
+		s.read(line.ProgramCounter, args[0])
+		st.push(st.sload(args[0]))
+
+	case opSSTORE:
+		s.write(line.ProgramCounter, args[0], args[1])
+		st.storage[args[0].String()] = args[1]
+
+	case opCALL, opCALLCODE, opDELEGATECALL, opSTATICCALL:
+		call := ExternalCall{PC: line.ProgramCounter, Op: op, To: args[1]}
+		in := 2
+		if op == opCALL || op == opCALLCODE {
+			call.Value = args[2]
+			in = 3
+		}
+		if size, ok := Const(args[in+1]); ok && !size.IsZero() {
+			call.Input = st.mload(args[in])
+		}
+		s.call(call)
+
+		st.forget(args[in+2], args[in+3])
+		st.push(&SymbolExpr{Name: fmt.Sprintf("success@%d", line.ProgramCounter)})
+
+	case opCREATE, opCREATE2:
+		s.call(ExternalCall{PC: line.ProgramCounter, Op: op, Value: args[0]})
+		st.push(&SymbolExpr{Name: fmt.Sprintf("created@%d", line.ProgramCounter)})
+
+	case opCALLDATACOPY, opCODECOPY, opRETURNDATACOPY, opMCOPY:
+		st.forget(args[0], args[2])
+
+	case opEXTCODECOPY:
+		st.forget(args[1], args[3])
+
+	case opMSTORE8:
+		st.forget(args[0], constUint64(1))
+
+	default:
+		switch {
+		case inst.OutCount == 0:
+		case inst.InCount == 0:
+			st.push(&SymbolExpr{Name: strings.ToLower(inst.Mnemonic)})
+		default:
+			st.push(newOpExpr(op, args...))
+		}
+	}
+}
+
+// symbolicState is the state of an execution path.
+type symbolicState struct {
+	pc    uint64
+	steps int
+	stack []Expr
+
+	// memory holds the words stored at the known offsets;
+	// if memoryUnknown is true, the rest of the memory is unknown rather than zero
+	memory        map[uint64]Expr
+	memoryUnknown bool
+
+	// storage holds the values written by the path by slot
+	storage map[string]Expr
+
+	constraints []Constraint
+}
+
+func newSymbolicState(pc uint64) *symbolicState {
+	return &symbolicState{
+		pc:      pc,
+		memory:  map[uint64]Expr{},
+		storage: map[string]Expr{},
+	}
+}
+
+func (st *symbolicState) clone() *symbolicState {
+	c := &symbolicState{
+		pc:            st.pc,
+		steps:         st.steps,
+		stack:         append([]Expr(nil), st.stack...),
+		memory:        make(map[uint64]Expr, len(st.memory)),
+		memoryUnknown: st.memoryUnknown,
+		storage:       make(map[string]Expr, len(st.storage)),
+		constraints:   append([]Constraint(nil), st.constraints...),
+	}
+
+	for k, v := range st.memory {
+		c.memory[k] = v
+	}
+	for k, v := range st.storage {
+		c.storage[k] = v
+	}
+
+	return c
+}
+
+// decided returns the value of the condition if it or its negation is a constraint of the path.
+func (st *symbolicState) decided(cond Expr) (bool, bool) {
+	key, negated := conditionKey(cond)
+	for _, c := range st.constraints {
+		if k, n := conditionKey(c.Cond); k == key {
+			return c.Taken != (n != negated), true
+		}
+	}
+
+	return false, false
+}
+
+// conditionKey strips the ISZERO negations of the condition,
+// and returns the remaining condition and whether the number of the stripped negations is odd.
+func conditionKey(cond Expr) (string, bool) {
+	negated := false
+	for {
+		op, ok := cond.(*OpExpr)
+		if !ok || op.Op != opISZERO {
+			return cond.String(), negated
+		}
+
+		cond = op.Args[0]
+		negated = !negated
+	}
+}
+
+func (st *symbolicState) push(e Expr) {
+	st.stack = append(st.stack, e)
+}
+
+func (st *symbolicState) pop() Expr {
+	e := st.stack[len(st.stack)-1]
+	st.stack = st.stack[:len(st.stack)-1]
+
+	return e
+}
+
+func (st *symbolicState) mload(offset Expr) Expr {
+	off, ok := Const(offset)
+	if ok && off.IsUint64() {
+		if v, ok := st.memory[off.Uint64()]; ok {
+			return v
+		}
+
+		if !st.memoryUnknown && !st.overlaps(off.Uint64(), 32) {
+			return constUint64(0)
+		}
+	}
+
+	return boundExpr(&OpExpr{Op: opMLOAD, Args: []Expr{offset}})
+}
+
+func (st *symbolicState) mstore(offset, value Expr) {
+	off, ok := Const(offset)
+	if !ok || !off.IsUint64() {
+		st.memory = map[uint64]Expr{}
+		st.memoryUnknown = true
+		return
+	}
+
+	o := off.Uint64()
+	for w, old := range st.memory {
+		if w >= o+32 || o >= w+32 {
+			continue
+		}
+
+		// the known head of the word stored below is kept, e.g. the selector of a call
+		if c, ok := Const(old); ok && w < o {
+			low := constUint64(8 * (32 - (o - w)))
+			head := newOpExpr(opSHL, low, newOpExpr(opSHR, low, constExpr(c)))
+			st.memory[w] = newOpExpr(opOR, head, newOpExpr(opSHR, constUint64(8*(o-w)), value))
+			continue
+		}
+
+		delete(st.memory, w)
+	}
+
+	st.memory[o] = value
+}
+
+func (st *symbolicState) sload(slot Expr) Expr {
+	if v, ok := st.storage[slot.String()]; ok {
+		return v
+	}
+
+	return boundExpr(&OpExpr{Op: opSLOAD, Args: []Expr{slot}})
+}
+
+// sha3 hashes the memory words of the range, if the range is known and word-aligned.
+func (st *symbolicState) sha3(offset, size Expr) Expr {
+	off, offOK := Const(offset)
+	n, sizeOK := Const(size)
+	if !offOK || !sizeOK || !off.IsUint64() || !n.IsUint64() || n.Uint64()%32 != 0 || n.Uint64()/32 > maxHashWords {
+		return newOpExpr(opSHA3, offset, size)
+	}
+
+	words := make([]Expr, n.Uint64()/32)
+	for i := range words {
+		words[i] = st.mload(constUint64(off.Uint64() + 32*uint64(i)))
+	}
+
+	return hashExpr(words)
+}
+
+// forget makes the memory range written by a copy or a call unknown.
+func (st *symbolicState) forget(offset, size Expr) {
+	n, ok := Const(size)
+	if ok && n.IsZero() {
+		return
+	}
+
+	off, offOK := Const(offset)
+	if !ok || !offOK || !off.IsUint64() || !n.IsUint64() ||
+		n.Uint64() > 32*maxForgetWords || off.Uint64() > math.MaxUint64-n.Uint64() {
+		st.memory = map[uint64]Expr{}
+		st.memoryUnknown = true
+		return
+	}
+
+	st.forgetRange(off.Uint64(), n.Uint64())
+	for i := uint64(0); i < (n.Uint64()+31)/32; i++ {
+		o := off.Uint64() + 32*i
+		st.memory[o] = &OpExpr{Op: opMLOAD, Args: []Expr{constUint64(o)}}
+	}
+}
+
+// forgetRange removes the words overlapping the memory range.
+func (st *symbolicState) forgetRange(offset, size uint64) {
+	for o := range st.memory {
+		if o < offset+size && offset < o+32 {
+			delete(st.memory, o)
+		}
+	}
+}
+
+func (st *symbolicState) overlaps(offset, size uint64) bool {
+	for o := range st.memory {
+		if o < offset+size && offset < o+32 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// summarizer collects the summary of the explored paths.
+type summarizer struct {
+	FunctionSummary
+
+	seen map[string]bool
+}
+
+func (s *summarizer) end(st *symbolicState, end PathEnd) {
+	s.Paths++
+
+	switch end {
+	case PathRevert, PathInvalid:
+		s.Reverts = append(s.Reverts, RevertCondition{PC: st.pc, End: end, Constraints: st.constraints})
+	case PathBounded:
+		s.Bounded = true
+	}
+}
+
+func (s *summarizer) read(pc uint64, slot Expr) {
+	if s.once(fmt.Sprintf("r%d %s", pc, slot)) {
+		s.Reads = append(s.Reads, StorageAccess{PC: pc, Slot: slot})
+	}
+}
+
+func (s *summarizer) write(pc uint64, slot, value Expr) {
+	if s.once(fmt.Sprintf("w%d %s %s", pc, slot, value)) {
+		s.Writes = append(s.Writes, StorageAccess{PC: pc, Slot: slot, Value: value})
+	}
+}
+
+func (s *summarizer) call(c ExternalCall) {
+	key := fmt.Sprintf("c%d %v %v %v", c.PC, c.To, c.Value, c.Input)
+	if s.once(key) {
+		s.Calls = append(s.Calls, c)
+	}
+}
+
+func (s *summarizer) once(key string) bool {
+	if s.seen[key] {
+		return false
+	}
+	s.seen[key] = true
+
+	return true
+}
+
+func (s *summarizer) finish() {
+	sort.SliceStable(s.Reads, func(i, j int) bool {
+		return s.Reads[i].PC < s.Reads[j].PC
+	})
+	sort.SliceStable(s.Writes, func(i, j int) bool {
+		return s.Writes[i].PC < s.Writes[j].PC
+	})
+	sort.SliceStable(s.Calls, func(i, j int) bool {
+		return s.Calls[i].PC < s.Calls[j].PC
+	})
+	sort.SliceStable(s.Reverts, func(i, j int) bool {
+		return s.Reverts[i].PC < s.Reverts[j].PC
+	})
+}
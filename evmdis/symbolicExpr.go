@@ -0,0 +1,225 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/holiman/uint256"
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// Opcodes with symbolic results formatted specially.
+const (
+	opSHA3  evmops.Opcode = 0x20
+	opSLOAD evmops.Opcode = 0x54
+)
+
+// Expr is a symbolic 256-bit value computed by the symbolic execution.
+// Expressions are immutable, and equal expressions have equal String.
+// Expressions too long to format are replaced with symbols named "expr#" followed by their hash.
+type Expr interface {
+	String() string
+}
+
+// ConstExpr is a known value.
+type ConstExpr struct {
+	Value uint256.Int
+}
+
+func (e *ConstExpr) String() string {
+	return e.Value.Hex()
+}
+
+// SymbolExpr is a value the code can't know in advance, e.g. "caller", "callvalue" or the result of a call.
+type SymbolExpr struct {
+	Name string
+}
+
+func (e *SymbolExpr) String() string {
+	return e.Name
+}
+
+// OpExpr is a result of an instruction with symbolic operands.
+//
+// The reads of the environment are formatted as calldata[offset], storage[slot] and memory[offset],
+// hashes of memory words as keccak256(word, ...), and the rest of the instructions as
+// lowercase mnemonics with the operands, top of the stack first, e.g. sub(calldatasize, 0x4).
+// The constant operands of the commutative operations go last.
+type OpExpr struct {
+	Op   evmops.Opcode
+	Args []Expr
+}
+
+func (e *OpExpr) String() string {
+	args := make([]string, len(e.Args))
+	for i, arg := range e.Args {
+		args[i] = arg.String()
+	}
+
+	switch e.Op {
+	case opCALLDATALOAD:
+		return "calldata[" + args[0] + "]"
+	case opSLOAD:
+		return "storage[" + args[0] + "]"
+	case opMLOAD:
+		return "memory[" + args[0] + "]"
+	case opSHA3:
+		return "keccak256(" + strings.Join(args, ", ") + ")"
+	}
+
+	return strings.ToLower(evmops.InstructionSet[e.Op].Mnemonic) + "(" + strings.Join(args, ", ") + ")"
+}
+
+// Const returns the value of the expression if it is known.
+func Const(e Expr) (*uint256.Int, bool) {
+	c, ok := e.(*ConstExpr)
+	if !ok {
+		return nil, false
+	}
+
+	return &c.Value, true
+}
+
+func constExpr(v *uint256.Int) *ConstExpr {
+	return &ConstExpr{Value: *v}
+}
+
+func constUint64(v uint64) *ConstExpr {
+	c := &ConstExpr{}
+	c.Value.SetUint64(v)
+
+	return c
+}
+
+// newOpExpr returns the result of the instruction, folding the constant operands
+// of the instructions evaluated by the stack analysis, and the trivial identities.
+func newOpExpr(op evmops.Opcode, args ...Expr) Expr {
+	consts := make([]stackValue, len(args))
+	folded := true
+	for i, arg := range args {
+		v, ok := Const(arg)
+		if !ok {
+			folded = false
+			break
+		}
+		consts[i] = stackValue{*v}
+	}
+
+	if folded {
+		var v stackValue
+		switch {
+		case (op == opNOT || op == opISZERO) && len(args) == 1:
+			v = evalUnary(op, consts[0])
+		case isEvaluated(op) && len(args) == 2:
+			v = evalBinary(op, consts[0], consts[1])
+		}
+
+		if len(v) == 1 {
+			return constExpr(&v[0])
+		}
+	}
+
+	if len(args) == 2 {
+		if e, ok := simplifyBinary(op, args[0], args[1]); ok {
+			return e
+		}
+
+		// the constant operand of the commutative operations goes last, so equal expressions are formatted equally
+		if _, ok := Const(args[0]); ok && isCommutative(op) {
+			args = []Expr{args[1], args[0]}
+		}
+	}
+
+	return boundExpr(&OpExpr{Op: op, Args: args})
+}
+
+// maxExprLength is the maximum length of the formatted expressions. The longer ones are replaced
+// with symbols named after their hash, so the expressions repeating an operand, e.g. made by DUP1 ADD,
+// don't grow exponentially.
+const maxExprLength = 1024
+
+// boundExpr returns the expression, or a symbol standing for it if it is too long.
+func boundExpr(e *OpExpr) Expr {
+	s := e.String()
+	if len(s) <= maxExprLength {
+		return e
+	}
+
+	return &SymbolExpr{Name: "expr#" + hex.EncodeToString(crypto.Keccak256([]byte(s))[:4])}
+}
+
+func isCommutative(op evmops.Opcode) bool {
+	switch op {
+	case opADD, opMUL, opEQ, opAND, opOR, opXOR:
+		return true
+	}
+
+	return false
+}
+
+// simplifyBinary applies the identities of the operations with one constant operand.
+func simplifyBinary(op evmops.Opcode, x, y Expr) (Expr, bool) {
+	for _, pair := range [][2]Expr{{x, y}, {y, x}} {
+		c, ok := Const(pair[0])
+		if !ok {
+			continue
+		}
+
+		switch {
+		case (op == opADD || op == opOR || op == opXOR) && c.IsZero():
+			return pair[1], true
+		case op == opMUL && c.IsZero(), op == opAND && c.IsZero():
+			return constUint64(0), true
+		case op == opMUL && c.Eq(uint256.NewInt(1)):
+			return pair[1], true
+		case op == opAND && c.Eq(new(uint256.Int).Not(new(uint256.Int))):
+			return pair[1], true
+		}
+	}
+
+	// shifts by zero, x is the shift
+	if c, ok := Const(x); ok && c.IsZero() && (op == opSHL || op == opSHR) {
+		return y, true
+	}
+
+	// x - 0, y is the subtrahend
+	if c, ok := Const(y); ok && c.IsZero() && op == opSUB {
+		return x, true
+	}
+
+	return nil, false
+}
+
+// hashExpr returns keccak256 of the memory words, computed if all of them are known.
+func hashExpr(words []Expr) Expr {
+	data := make([]byte, 0, 32*len(words))
+	for _, w := range words {
+		v, ok := Const(w)
+		if !ok {
+			return boundExpr(&OpExpr{Op: opSHA3, Args: words})
+		}
+
+		b := v.Bytes32()
+		data = append(data, b[:]...)
+	}
+
+	var v uint256.Int
+	v.SetBytes(crypto.Keccak256(data))
+
+	return constExpr(&v)
+}
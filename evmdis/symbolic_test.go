@@ -0,0 +1,265 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmdis
+
+import (
+	"encoding/hex"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/kirillDanshin/evmtools/evmasm"
+	"github.com/kirillDanshin/evmtools/evmops"
+)
+
+// symbolicSource dispatches the selector 0x11111111 to a non-payable function, that adds
+// the second argument to the mapping entry of the first one, and calls transfer(address,uint256)
+// of the first argument. The selector 0x22222222 loops on a calldata flag.
+const symbolicSource = `
+	PUSH1 0x00
+	CALLDATALOAD
+	PUSH1 0xe0
+	SHR
+	DUP1
+	PUSH4 0x11111111
+	EQ
+	PUSH @fn
+	JUMPI
+	DUP1
+	PUSH4 0x22222222
+	EQ
+	PUSH @loop
+	JUMPI
+	STOP
+fn:
+	JUMPDEST
+	CALLVALUE
+	ISZERO
+	PUSH @nonpayable
+	JUMPI
+	PUSH1 0x00
+	DUP1
+	REVERT
+nonpayable:
+	JUMPDEST
+	PUSH1 0x04
+	CALLDATALOAD
+	PUSH1 0x00
+	MSTORE
+	PUSH1 0x01
+	PUSH1 0x20
+	MSTORE
+	PUSH1 0x40
+	PUSH1 0x00
+	SHA3
+	DUP1
+	SLOAD
+	PUSH1 0x24
+	CALLDATALOAD
+	ADD
+	SWAP1
+	SSTORE
+	PUSH4 0xa9059cbb
+	PUSH1 0xe0
+	SHL
+	PUSH1 0x80
+	MSTORE
+	CALLER
+	PUSH1 0x84
+	MSTORE
+	PUSH1 0x00
+	PUSH1 0x00
+	PUSH1 0x44
+	PUSH1 0x80
+	PUSH1 0x00
+	PUSH1 0x04
+	CALLDATALOAD
+	GAS
+	CALL
+	ISZERO
+	PUSH @failed
+	JUMPI
+	STOP
+failed:
+	JUMPDEST
+	INVALID
+loop:
+	JUMPDEST
+	PUSH1 0x04
+	CALLDATALOAD
+	PUSH @loop
+	JUMPI
+	STOP
+`
+
+func TestSymbolicExecutor_Summarize(t *testing.T) {
+	code, err := evmasm.Assemble(symbolicSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r := disassembleOffline(t, hex.EncodeToString(code))
+	if r.Dispatcher == nil || len(r.Dispatcher.Entries) != 2 {
+		t.Fatalf("Dispatcher = %+v, want 2 entries", r.Dispatcher)
+	}
+
+	summaries := NewSymbolicExecutor(r).SummarizeAll()
+
+	fn := summaries[0]
+	if fn.Paths != 3 || fn.Bounded {
+		t.Errorf("Paths = %d, Bounded = %v, want 3 paths, not bounded", fn.Paths, fn.Bounded)
+	}
+
+	wantSlot := "keccak256(calldata[0x4], 0x1)"
+	if len(fn.Reads) != 1 || fn.Reads[0].Slot.String() != wantSlot {
+		t.Errorf("Reads = %v, want %s", fn.Reads, wantSlot)
+	}
+
+	wantValue := "add(calldata[0x24], storage[" + wantSlot + "])"
+	if len(fn.Writes) != 1 || fn.Writes[0].Slot.String() != wantSlot || fn.Writes[0].Value.String() != wantValue {
+		t.Errorf("Writes = %v, want %s = %s", fn.Writes, wantSlot, wantValue)
+	}
+
+	if len(fn.Calls) != 1 {
+		t.Fatalf("Calls = %v, want 1 call", fn.Calls)
+	}
+	call := fn.Calls[0]
+	if call.Op != opCALL || call.To.String() != "calldata[0x4]" || call.Value.String() != "0x0" {
+		t.Errorf("Calls[0] = %s to %s value %s, want CALL to calldata[0x4] value 0x0", evmops.InstructionSet[call.Op].Mnemonic, call.To, call.Value)
+	}
+	if sel, ok := call.Selector(); !ok || hex.EncodeToString(sel[:]) != "a9059cbb" {
+		t.Errorf("Calls[0].Selector() = %x, %v, want a9059cbb", sel, ok)
+	}
+
+	var reverts []string
+	for _, rc := range fn.Reverts {
+		reverts = append(reverts, rc.String())
+	}
+	wantReverts := []string{
+		"pc=36: revert if !iszero(callvalue)",
+		"pc=98: invalid if iszero(callvalue) && iszero(success@91)",
+	}
+	if !reflect.DeepEqual(reverts, wantReverts) {
+		t.Errorf("Reverts = %q, want %q", reverts, wantReverts)
+	}
+
+	loop := summaries[1]
+	if !loop.Bounded || loop.Paths != 2 {
+		t.Errorf("loop Paths = %d, Bounded = %v, want 2 paths, bounded", loop.Paths, loop.Bounded)
+	}
+}
+
+func TestSymbolicExecutor_contract(t *testing.T) {
+	r := disassembleOffline(t, uniswapRuntimeCode)
+	e := NewSymbolicExecutor(r, WithMaxPaths(64), WithMaxDepth(16))
+
+	tests := []struct {
+		sig       string
+		wantReads []string
+	}{
+		{"balanceOf(address)", []string{"keccak256(and(calldata[0x4], 0xffffffffffffffffffffffffffffffffffffffff), 0x4)"}},
+		{"totalSupply()", []string{"0x0"}},
+		{"minter()", []string{"0x1"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sig, func(t *testing.T) {
+			entry, ok := r.Dispatcher.LookupSignature(tt.sig)
+			if !ok {
+				t.Fatalf("%s is not in the dispatcher", tt.sig)
+			}
+
+			s := e.Summarize(entry)
+
+			var reads []string
+			for _, a := range s.Reads {
+				reads = append(reads, a.Slot.String())
+			}
+			if !reflect.DeepEqual(reads, tt.wantReads) {
+				t.Errorf("Reads = %q, want %q", reads, tt.wantReads)
+			}
+			if len(s.Writes) != 0 || len(s.Calls) != 0 {
+				t.Errorf("Writes = %v, Calls = %v, want none", s.Writes, s.Calls)
+			}
+		})
+	}
+
+	for _, s := range e.SummarizeAll() {
+		if s.Paths == 0 || s.Paths > 64 {
+			t.Errorf("%s: Paths = %d, want 1..64", s.Entry.Signature, s.Paths)
+		}
+	}
+}
+
+func TestSymbolicExecutor_Bounds(t *testing.T) {
+	tests := []struct {
+		name      string
+		src       string
+		wantValue string
+	}{
+		{
+			name: "huge_copy",
+			src: `
+				PUSH8 0xffffffffffffffff
+				PUSH1 0x00
+				PUSH1 0x00
+				CALLDATACOPY
+				PUSH1 0x00
+				MLOAD
+				PUSH1 0x00
+				SSTORE
+				STOP
+			`,
+			wantValue: "memory[0x0]",
+		},
+		{
+			name: "wrapping_copy",
+			src: `
+				PUSH1 0x40
+				PUSH1 0x00
+				PUSH8 0xffffffffffffffe0
+				CALLDATACOPY
+				PUSH1 0x00
+				MLOAD
+				PUSH1 0x00
+				SSTORE
+				STOP
+			`,
+			wantValue: "memory[0x0]",
+		},
+		{
+			name:      "repeated_operand",
+			src:       "PUSH1 0x00\nCALLDATALOAD\n" + strings.Repeat("DUP1\nADD\n", 40) + "PUSH1 0x00\nSSTORE\nSTOP",
+			wantValue: "expr#",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, err := evmasm.Assemble(tt.src)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			s := NewSymbolicExecutor(disassembleOffline(t, hex.EncodeToString(code))).Summarize(DispatchEntry{})
+			if len(s.Writes) != 1 {
+				t.Fatalf("Writes = %v, want 1 write", s.Writes)
+			}
+
+			value := s.Writes[0].Value.String()
+			if !strings.Contains(value, tt.wantValue) || len(value) > maxExprLength {
+				t.Errorf("Writes[0].Value = %s, want %s", value, tt.wantValue)
+			}
+		})
+	}
+}
@@ -1,17 +1,37 @@
 package evmfuncs
 
-type ArgKind uint8
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
 
-// TODO: support fixed and ufixed
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// ArgKind is the kind of an ABI type.
+type ArgKind uint8
 
 const (
 	ArgKindUnknown ArgKind = iota
 	ArgKindAddress
 	ArgKindBool
+	// ArgKindBytes is the dynamic bytes type
 	ArgKindBytes
 	ArgKindInt
 	ArgKindString
 	ArgKindUint
+	// ArgKindFixedBytes is one of bytes1 to bytes32
+	ArgKindFixedBytes
+	ArgKindFixed
+	ArgKindUfixed
+	// ArgKindFunction is an address followed by a function selector
+	ArgKindFunction
+	// ArgKindArray is a fixed-length array, e.g. uint256[2]
+	ArgKindArray
+	// ArgKindSlice is a dynamic array, e.g. uint256[]
+	ArgKindSlice
+	ArgKindTuple
 )
 
 func (k ArgKind) String() string {
@@ -30,25 +50,301 @@ func (k ArgKind) String() string {
 		return "string"
 	case ArgKindUint:
 		return "uint"
+	case ArgKindFixedBytes:
+		return "fixed bytes"
+	case ArgKindFixed:
+		return "fixed"
+	case ArgKindUfixed:
+		return "ufixed"
+	case ArgKindFunction:
+		return "function"
+	case ArgKindArray:
+		return "array"
+	case ArgKindSlice:
+		return "slice"
+	case ArgKindTuple:
+		return "tuple"
 	default:
 		return "invalid"
 	}
 }
 
+// ArgType is an ABI type. Use the constructors or ParseArgType to create the types,
+// so equal types are deeply equal.
 type ArgType struct {
-	Kind      ArgKind
-	OffsetLen int
-}
+	Kind ArgKind
 
-func (t ArgType) String() string {
-	return t.Kind.String()
+	// Size is the bit width of int, uint, fixed and ufixed, the length of bytesN,
+	// and the length of fixed-length arrays
+	Size int
+
+	// Decimals is the number of decimals of fixed and ufixed
+	Decimals int
+
+	// Elem is the element type of arrays and slices
+	Elem *ArgType
+
+	// Components are the components of tuples
+	Components []FuncParam
 }
 
 var (
-	ArgTypeUnknown = ArgType{Kind: ArgKindUnknown}
-	ArgTypeAddress = ArgType{Kind: ArgKindAddress, OffsetLen: 20}
-	ArgTypeBool    = ArgType{Kind: ArgKindBool, OffsetLen: 32}
-	ArgTypeString  = ArgType{Kind: ArgKindString}
+	ArgTypeUnknown  = ArgType{Kind: ArgKindUnknown}
+	ArgTypeAddress  = ArgType{Kind: ArgKindAddress}
+	ArgTypeBool     = ArgType{Kind: ArgKindBool}
+	ArgTypeBytes    = ArgType{Kind: ArgKindBytes}
+	ArgTypeString   = ArgType{Kind: ArgKindString}
+	ArgTypeFunction = ArgType{Kind: ArgKindFunction}
 
-	ArgTypeUint = ArgType{Kind: ArgKindUint, OffsetLen: 32}
+	ArgTypeUint = UintType(256)
+	ArgTypeInt  = IntType(256)
 )
+
+// UintType returns the uint type of the bit width, e.g. UintType(8) is uint8.
+func UintType(bits int) ArgType {
+	return ArgType{Kind: ArgKindUint, Size: bits}
+}
+
+// IntType returns the int type of the bit width, e.g. IntType(128) is int128.
+func IntType(bits int) ArgType {
+	return ArgType{Kind: ArgKindInt, Size: bits}
+}
+
+// FixedType returns the fixed type, e.g. FixedType(128, 18) is fixed128x18.
+func FixedType(bits, decimals int) ArgType {
+	return ArgType{Kind: ArgKindFixed, Size: bits, Decimals: decimals}
+}
+
+// UfixedType returns the ufixed type, e.g. UfixedType(128, 18) is ufixed128x18.
+func UfixedType(bits, decimals int) ArgType {
+	return ArgType{Kind: ArgKindUfixed, Size: bits, Decimals: decimals}
+}
+
+// FixedBytesType returns the bytesN type, e.g. FixedBytesType(4) is bytes4.
+func FixedBytesType(size int) ArgType {
+	return ArgType{Kind: ArgKindFixedBytes, Size: size}
+}
+
+// ArrayType returns the fixed-length array type, e.g. ArrayType(ArgTypeAddress, 2) is address[2].
+func ArrayType(elem ArgType, length int) ArgType {
+	return ArgType{Kind: ArgKindArray, Size: length, Elem: &elem}
+}
+
+// SliceType returns the dynamic array type, e.g. SliceType(ArgTypeAddress) is address[].
+func SliceType(elem ArgType) ArgType {
+	return ArgType{Kind: ArgKindSlice, Elem: &elem}
+}
+
+// TupleType returns the tuple type of the components.
+func TupleType(components ...FuncParam) ArgType {
+	return ArgType{Kind: ArgKindTuple, Components: components}
+}
+
+// ParseArgType parses a type, canonical or not, e.g. "uint", "(address to, uint256 amount)[]"
+// or "tuple(bytes32,bool)".
+func ParseArgType(typ string) (ArgType, error) {
+	p := &sigParser{s: typ}
+
+	p.skipSpace()
+	t, err := p.argType()
+	if err != nil {
+		return ArgTypeUnknown, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return ArgTypeUnknown, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	return t, nil
+}
+
+func mustParseArgType(typ string) ArgType {
+	t, err := ParseArgType(typ)
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// String returns the canonical type, as used in the function selectors.
+func (t ArgType) String() string {
+	switch t.Kind {
+	case ArgKindInt, ArgKindUint:
+		return t.Kind.String() + strconv.Itoa(t.Size)
+	case ArgKindFixed, ArgKindUfixed:
+		return fmt.Sprintf("%s%dx%d", t.Kind, t.Size, t.Decimals)
+	case ArgKindFixedBytes:
+		return "bytes" + strconv.Itoa(t.Size)
+	case ArgKindArray:
+		return t.Elem.String() + "[" + strconv.Itoa(t.Size) + "]"
+	case ArgKindSlice:
+		return t.Elem.String() + "[]"
+	case ArgKindTuple:
+		types := make([]string, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type.String()
+		}
+
+		return "(" + strings.Join(types, ",") + ")"
+	default:
+		return t.Kind.String()
+	}
+}
+
+// IsDynamic returns true if the encoded value is placed in the tail of the enclosing tuple,
+// and its head holds the offset.
+func (t ArgType) IsDynamic() bool {
+	switch t.Kind {
+	case ArgKindBytes, ArgKindString, ArgKindSlice:
+		return true
+	case ArgKindArray:
+		return t.Elem.IsDynamic()
+	case ArgKindTuple:
+		for _, c := range t.Components {
+			if c.Type.IsDynamic() {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// maxHeadSize caps HeadSize of the huge static types, it is far beyond the size of any encoded value.
+const maxHeadSize = math.MaxInt32
+
+// HeadSize returns the size in bytes the value takes in the head of the enclosing tuple:
+// the size of the static values, and 32 bytes of the offset for the dynamic ones.
+// The size of the static types larger than 2^31-1 bytes is clamped to 2^31-1, so it never overflows.
+func (t ArgType) HeadSize() int {
+	if t.IsDynamic() {
+		return 32
+	}
+
+	switch t.Kind {
+	case ArgKindArray:
+		elem := t.Elem.HeadSize()
+		if elem > 0 && t.Size > maxHeadSize/elem {
+			return maxHeadSize
+		}
+
+		return t.Size * elem
+	case ArgKindTuple:
+		size := 0
+		for _, c := range t.Components {
+			head := c.Type.HeadSize()
+			if head > maxHeadSize-size {
+				return maxHeadSize
+			}
+			size += head
+		}
+
+		return size
+	default:
+		return 32
+	}
+}
+
+// ABIType converts the type to the go-ethereum ABI type.
+//
+// go-ethereum doesn't support fixed and ufixed, so they are converted to the integers
+// of the same width, holding the values multiplied by 10^Decimals.
+func (t ArgType) ABIType() (abi.Type, error) {
+	typ, components := t.abiMarshaling()
+
+	return abi.NewType(typ, "", components)
+}
+
+// abiMarshaling returns the go-ethereum notation of the type, e.g. "tuple[]", and the tuple components.
+func (t ArgType) abiMarshaling() (string, []abi.ArgumentMarshaling) {
+	switch t.Kind {
+	case ArgKindArray, ArgKindSlice:
+		typ, components := t.Elem.abiMarshaling()
+		suffix := "[]"
+		if t.Kind == ArgKindArray {
+			suffix = "[" + strconv.Itoa(t.Size) + "]"
+		}
+
+		return typ + suffix, components
+	case ArgKindTuple:
+		components := make([]abi.ArgumentMarshaling, len(t.Components))
+		for i, c := range t.Components {
+			components[i].Name = abiFieldName(c.Name, i)
+			components[i].Type, components[i].Components = c.Type.abiMarshaling()
+		}
+
+		return "tuple", components
+	case ArgKindFixed:
+		return IntType(t.Size).String(), nil
+	case ArgKindUfixed:
+		return UintType(t.Size).String(), nil
+	default:
+		return t.String(), nil
+	}
+}
+
+// abiFieldName returns the name of the i-th tuple component passed to go-ethereum, which represents tuples
+// as structs. The names that can't be exported struct fields, e.g. "", "_" or "$x", are replaced with
+// "field%d"; the names don't affect the encoding.
+func abiFieldName(name string, i int) string {
+	field := abi.ToCamelCase(name)
+	if field == "" || field[0] < 'A' || field[0] > 'Z' || strings.ContainsRune(field, '$') {
+		return fmt.Sprintf("field%d", i)
+	}
+
+	return name
+}
+
+// ArgTypeFromABI converts the go-ethereum ABI type, keeping the names of the tuple components.
+func ArgTypeFromABI(t abi.Type) (ArgType, error) {
+	switch t.T {
+	case abi.IntTy:
+		return IntType(t.Size), nil
+	case abi.UintTy:
+		return UintType(t.Size), nil
+	case abi.BoolTy:
+		return ArgTypeBool, nil
+	case abi.StringTy:
+		return ArgTypeString, nil
+	case abi.AddressTy:
+		return ArgTypeAddress, nil
+	case abi.BytesTy:
+		return ArgTypeBytes, nil
+	case abi.FixedBytesTy:
+		return FixedBytesType(t.Size), nil
+	case abi.HashTy:
+		return FixedBytesType(32), nil
+	case abi.FunctionTy:
+		return ArgTypeFunction, nil
+	case abi.SliceTy, abi.ArrayTy:
+		elem, err := ArgTypeFromABI(*t.Elem)
+		if err != nil {
+			return ArgTypeUnknown, err
+		}
+
+		if t.T == abi.SliceTy {
+			return SliceType(elem), nil
+		}
+
+		return ArrayType(elem, t.Size), nil
+	case abi.TupleTy:
+		components := make([]FuncParam, len(t.TupleElems))
+		for i, elem := range t.TupleElems {
+			typ, err := ArgTypeFromABI(*elem)
+			if err != nil {
+				return ArgTypeUnknown, err
+			}
+
+			components[i] = FuncParam{Type: typ}
+			if i < len(t.TupleRawNames) {
+				components[i].Name = t.TupleRawNames[i]
+			}
+		}
+
+		return TupleType(components...), nil
+	default:
+		return ArgTypeUnknown, fmt.Errorf("evmfuncs: unsupported ABI type %s", t.String())
+	}
+}
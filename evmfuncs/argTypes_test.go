@@ -0,0 +1,144 @@
+package evmfuncs
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestParseArgType(t *testing.T) {
+	tests := []struct {
+		typ         string
+		want        string
+		wantDynamic bool
+		wantHead    int
+		wantErr     bool
+	}{
+		{typ: "uint", want: "uint256", wantHead: 32},
+		{typ: "int8", want: "int8", wantHead: 32},
+		{typ: "byte", want: "bytes1", wantHead: 32},
+		{typ: "fixed", want: "fixed128x18", wantHead: 32},
+		{typ: "ufixed64x10", want: "ufixed64x10", wantHead: 32},
+		{typ: "function", want: "function", wantHead: 32},
+		{typ: "bytes", want: "bytes", wantDynamic: true, wantHead: 32},
+		{typ: "address[3][2]", want: "address[3][2]", wantHead: 192},
+		{typ: "uint[3][]", want: "uint256[3][]", wantDynamic: true, wantHead: 32},
+		{typ: "string[2]", want: "string[2]", wantDynamic: true, wantHead: 32},
+		{typ: "tuple(address to, uint amount)[2]", want: "(address,uint256)[2]", wantHead: 128},
+		{typ: "(bool,(bytes32,string))", want: "(bool,(bytes32,string))", wantDynamic: true, wantHead: 32},
+		{typ: "()", want: "()", wantHead: 0},
+		{typ: "uint257", wantErr: true},
+		{typ: "int0", wantErr: true},
+		{typ: "uint08", wantErr: true},
+		{typ: "fixed128x81", wantErr: true},
+		{typ: "bytes0", wantErr: true},
+		{typ: "uint256[0]", wantErr: true},
+		{typ: "uint256 x", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			got, err := ParseArgType(tt.typ)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArgType() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if got.String() != tt.want {
+				t.Errorf("ArgType.String() = %q, want %q", got.String(), tt.want)
+			}
+			if got.IsDynamic() != tt.wantDynamic {
+				t.Errorf("ArgType.IsDynamic() = %v, want %v", got.IsDynamic(), tt.wantDynamic)
+			}
+			if got.HeadSize() != tt.wantHead {
+				t.Errorf("ArgType.HeadSize() = %d, want %d", got.HeadSize(), tt.wantHead)
+			}
+		})
+	}
+}
+
+func TestArgType_HeadSize(t *testing.T) {
+	tests := []struct {
+		name string
+		typ  ArgType
+		want int
+	}{
+		{name: "array", typ: ArrayType(ArgTypeUint, 1000), want: 32000},
+		{name: "huge_array", typ: ArrayType(ArgTypeUint, math.MaxInt64), want: math.MaxInt32},
+		{name: "huge_nested_array", typ: ArrayType(ArrayType(ArgTypeUint, 1e12), 1e12), want: math.MaxInt32},
+		{
+			name: "huge_tuple",
+			typ:  TupleType(FuncParam{Type: ArrayType(ArgTypeUint, 1<<25)}, FuncParam{Type: ArrayType(ArgTypeUint, 1<<25)}),
+			want: math.MaxInt32,
+		},
+		{name: "dynamic_array", typ: ArrayType(ArgTypeString, 1e12), want: 32},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.typ.HeadSize(); got != tt.want {
+				t.Errorf("ArgType.HeadSize() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgType_ABIType(t *testing.T) {
+	tests := []struct {
+		typ     string
+		wantABI string
+		// wantBack is the type converted back from the go-ethereum type, if it differs
+		wantBack string
+	}{
+		{typ: "uint8", wantABI: "uint8"},
+		{typ: "bytes4[]", wantABI: "bytes4[]"},
+		{typ: "(address,uint256)[2]", wantABI: "(address,uint256)[2]"},
+		{typ: "(bool,(string,bytes32[3]))[]", wantABI: "(bool,(string,bytes32[3]))[]"},
+		{typ: "fixed128x18", wantABI: "int128", wantBack: "int128"},
+		{typ: "ufixed64x10[]", wantABI: "uint64[]", wantBack: "uint64[]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ, func(t *testing.T) {
+			typ := mustParseArgType(tt.typ)
+
+			abiType, err := typ.ABIType()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if abiType.String() != tt.wantABI {
+				t.Errorf("ArgType.ABIType() = %s, want %s", abiType.String(), tt.wantABI)
+			}
+
+			back, err := ArgTypeFromABI(abiType)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			want := tt.wantBack
+			if want == "" {
+				want = tt.typ
+			}
+			if back.String() != want {
+				t.Errorf("ArgTypeFromABI() = %s, want %s", back.String(), want)
+			}
+		})
+	}
+}
+
+func TestArgTypeFromABI_Names(t *testing.T) {
+	typ := mustParseArgType("(address to, uint256 amount)[]")
+
+	abiType, err := typ.ABIType()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	back, err := ArgTypeFromABI(abiType)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(back, typ) {
+		t.Errorf("ArgTypeFromABI() = %+v, want %+v", back, typ)
+	}
+}
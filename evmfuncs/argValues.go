@@ -0,0 +1,343 @@
+package evmfuncs
+
+import (
+	"fmt"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// maxExponent limits the exponents of the numbers in the scientific notation.
+const maxExponent = 1000
+
+// ParseArgValues converts the string arguments to the Go values of the parameter types,
+// as accepted by FuncSignature.PackInput and PackOutput. See ParseArgValue for the syntax.
+func ParseArgValues(params []FuncParam, args []string) ([]interface{}, error) {
+	if len(args) != len(params) {
+		return nil, fmt.Errorf("evmfuncs: got %d arguments, want %d", len(args), len(params))
+	}
+
+	values := make([]interface{}, len(args))
+	for i, arg := range args {
+		v, err := parseTopArgValue(params[i].Type, arg)
+		if err != nil {
+			return nil, fmt.Errorf("evmfuncs: argument %d: %w", i, err)
+		}
+
+		values[i] = v
+	}
+
+	return values, nil
+}
+
+// ParseArgValue converts the string to the Go value of the type, as accepted by go-ethereum ABI encoding:
+//
+//   - integers are decimal, e.g. "-42", in the scientific notation, e.g. "1e18" or "1.5e18",
+//     or 0x-prefixed hex, e.g. "0xff"
+//   - fixed and ufixed are decimal, e.g. "1.25", converted to the integers multiplied by 10^decimals;
+//     hex numbers are taken as is
+//   - addresses are 0x-prefixed hex, and the checksum is verified if the address is mixed-case
+//   - booleans are "true" and "false"
+//   - bytes, bytesN and function are 0x-prefixed hex, bytesN of exactly N bytes
+//   - strings are taken as is, but inside arrays and tuples they are either quoted using Go syntax,
+//     or must not contain commas, brackets and parentheses
+//   - arrays are comma-separated elements in brackets, e.g. "[1, 2]",
+//     and tuples are comma-separated components in parentheses, e.g. "(0x..., [1, 2], true)"
+func ParseArgValue(typ ArgType, s string) (interface{}, error) {
+	v, err := parseTopArgValue(typ, s)
+	if err != nil {
+		return nil, fmt.Errorf("evmfuncs: %w", err)
+	}
+
+	return v, nil
+}
+
+func parseTopArgValue(typ ArgType, s string) (interface{}, error) {
+	abiType, err := typ.ABIType()
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := parseArgValue(typ, abiType, s, false)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.Interface(), nil
+}
+
+// parseArgValue parses the value of the type, abiType is the go-ethereum counterpart of the type,
+// nested is true for elements of arrays and tuples.
+func parseArgValue(typ ArgType, abiType abi.Type, s string, nested bool) (reflect.Value, error) {
+	if typ.Kind != ArgKindString || nested {
+		s = strings.TrimSpace(s)
+	}
+
+	switch typ.Kind {
+	case ArgKindInt, ArgKindUint, ArgKindFixed, ArgKindUfixed:
+		decimals := 0
+		if typ.Kind == ArgKindFixed || typ.Kind == ArgKindUfixed {
+			decimals = typ.Decimals
+		}
+
+		x, err := parseNumber(s, decimals)
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		signed := typ.Kind == ArgKindInt || typ.Kind == ArgKindFixed
+		if err := checkIntRange(x, typ.Size, signed); err != nil {
+			return reflect.Value{}, fmt.Errorf("%s: %w", s, err)
+		}
+
+		goType := abiType.GetType()
+		switch {
+		case goType == reflect.TypeOf(&big.Int{}):
+			return reflect.ValueOf(x), nil
+		case signed:
+			return reflect.ValueOf(x.Int64()).Convert(goType), nil
+		default:
+			return reflect.ValueOf(x.Uint64()).Convert(goType), nil
+		}
+
+	case ArgKindAddress:
+		if !strings.HasPrefix(s, "0x") || !common.IsHexAddress(s) {
+			return reflect.Value{}, fmt.Errorf("invalid address %q", s)
+		}
+
+		addr := common.HexToAddress(s)
+		if hex := s[2:]; hex != strings.ToLower(hex) && hex != strings.ToUpper(hex) && addr.Hex() != s {
+			return reflect.Value{}, fmt.Errorf("invalid address checksum %q", s)
+		}
+
+		return reflect.ValueOf(addr), nil
+
+	case ArgKindBool:
+		switch s {
+		case "true":
+			return reflect.ValueOf(true), nil
+		case "false":
+			return reflect.ValueOf(false), nil
+		}
+
+		return reflect.Value{}, fmt.Errorf("invalid bool %q", s)
+
+	case ArgKindString:
+		if nested && strings.HasPrefix(s, `"`) {
+			unquoted, err := strconv.Unquote(s)
+			if err != nil {
+				return reflect.Value{}, fmt.Errorf("invalid string %s", s)
+			}
+
+			return reflect.ValueOf(unquoted), nil
+		}
+
+		return reflect.ValueOf(s), nil
+
+	case ArgKindBytes:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid bytes %q: %w", s, err)
+		}
+
+		return reflect.ValueOf(b), nil
+
+	case ArgKindFixedBytes, ArgKindFunction:
+		b, err := hexutil.Decode(s)
+		if err != nil {
+			return reflect.Value{}, fmt.Errorf("invalid %s %q: %w", typ, s, err)
+		}
+
+		v := reflect.New(abiType.GetType()).Elem()
+		if len(b) != v.Len() {
+			return reflect.Value{}, fmt.Errorf("invalid %s %q: got %d bytes", typ, s, len(b))
+		}
+		reflect.Copy(v, reflect.ValueOf(b))
+
+		return v, nil
+
+	case ArgKindArray, ArgKindSlice:
+		elems, err := splitList(s, '[', ']')
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		var v reflect.Value
+		if typ.Kind == ArgKindArray {
+			if len(elems) != typ.Size {
+				return reflect.Value{}, fmt.Errorf("%s: got %d elements, want %d", typ, len(elems), typ.Size)
+			}
+			v = reflect.New(abiType.GetType()).Elem()
+		} else {
+			v = reflect.MakeSlice(abiType.GetType(), len(elems), len(elems))
+		}
+
+		for i, elem := range elems {
+			ev, err := parseArgValue(*typ.Elem, *abiType.Elem, elem, true)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.Index(i).Set(ev)
+		}
+
+		return v, nil
+
+	case ArgKindTuple:
+		components, err := splitList(s, '(', ')')
+		if err != nil {
+			return reflect.Value{}, err
+		}
+
+		if len(components) != len(typ.Components) {
+			return reflect.Value{}, fmt.Errorf("%s: got %d components, want %d", typ, len(components), len(typ.Components))
+		}
+
+		v := reflect.New(abiType.TupleType).Elem()
+		for i, c := range components {
+			cv, err := parseArgValue(typ.Components[i].Type, *abiType.TupleElems[i], c, true)
+			if err != nil {
+				return reflect.Value{}, err
+			}
+			v.Field(i).Set(cv)
+		}
+
+		return v, nil
+	}
+
+	return reflect.Value{}, fmt.Errorf("unsupported type %s", typ)
+}
+
+// parseNumber parses the decimal, scientific or hex number, multiplied by 10^decimals unless hex.
+func parseNumber(s string, decimals int) (*big.Int, error) {
+	digits := s
+	neg := strings.HasPrefix(digits, "-")
+	if neg {
+		digits = digits[1:]
+	}
+
+	x := new(big.Int)
+	if strings.HasPrefix(digits, "0x") || strings.HasPrefix(digits, "0X") {
+		if _, ok := x.SetString(digits[2:], 16); !ok || strings.HasPrefix(digits[2:], "-") {
+			return nil, fmt.Errorf("invalid number %q", s)
+		}
+	} else {
+		mantissa, exp := digits, 0
+		if i := strings.IndexAny(digits, "eE"); i >= 0 {
+			var err error
+			if exp, err = strconv.Atoi(digits[i+1:]); err != nil || exp > maxExponent || exp < -maxExponent {
+				return nil, fmt.Errorf("invalid number %q", s)
+			}
+			mantissa = digits[:i]
+		}
+
+		intPart, frac := mantissa, ""
+		if i := strings.IndexByte(mantissa, '.'); i >= 0 {
+			intPart, frac = mantissa[:i], mantissa[i+1:]
+		}
+
+		if intPart+frac == "" || strings.Trim(intPart+frac, "0123456789") != "" {
+			return nil, fmt.Errorf("invalid number %q", s)
+		}
+
+		x.SetString(intPart+frac, 10)
+
+		shift := exp + decimals - len(frac)
+		pow := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(shift))), nil)
+		if shift >= 0 {
+			x.Mul(x, pow)
+		} else {
+			var rem big.Int
+			if x.DivMod(x, pow, &rem); rem.Sign() != 0 {
+				return nil, fmt.Errorf("%s: too many decimals", s)
+			}
+		}
+	}
+
+	if neg {
+		x.Neg(x)
+	}
+
+	return x, nil
+}
+
+// checkIntRange returns an error if x doesn't fit the integer of the bit width.
+func checkIntRange(x *big.Int, bits int, signed bool) error {
+	if !signed {
+		if x.Sign() < 0 || x.BitLen() > bits {
+			return fmt.Errorf("out of uint%d range", bits)
+		}
+
+		return nil
+	}
+
+	// -2^(bits-1) <= x <= 2^(bits-1)-1, i.e. both x and -x-1 fit bits-1 unsigned bits
+	y := x
+	if x.Sign() < 0 {
+		y = new(big.Int).Not(x)
+	}
+
+	if y.BitLen() > bits-1 {
+		return fmt.Errorf("out of int%d range", bits)
+	}
+
+	return nil
+}
+
+// splitList splits the comma-separated list in the brackets at the top level,
+// skipping nested brackets and quoted strings.
+func splitList(s string, open, close byte) ([]string, error) {
+	if len(s) < 2 || s[0] != open || s[len(s)-1] != close {
+		return nil, fmt.Errorf("expected %c...%c, got %q", open, close, s)
+	}
+
+	inner := s[1 : len(s)-1]
+	if strings.TrimSpace(inner) == "" {
+		return nil, nil
+	}
+
+	var (
+		items  []string
+		depth  int
+		quoted bool
+		start  int
+	)
+	for i := 0; i < len(inner); i++ {
+		c := inner[i]
+		switch {
+		case quoted && c == '\\':
+			i++
+		case c == '"':
+			quoted = !quoted
+		case quoted:
+		case c == '[' || c == '(':
+			depth++
+		case c == ']' || c == ')':
+			depth--
+			if depth < 0 {
+				return nil, fmt.Errorf("unbalanced %q", s)
+			}
+		case c == ',' && depth == 0:
+			items = append(items, inner[start:i])
+			start = i + 1
+		}
+	}
+
+	if depth != 0 || quoted {
+		return nil, fmt.Errorf("unbalanced %q", s)
+	}
+
+	return append(items, inner[start:]), nil
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+
+	return x
+}
@@ -0,0 +1,171 @@
+package evmfuncs
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/kirillDanshin/evmtools"
+)
+
+func TestParseArgValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     string
+		s       string
+		want    interface{}
+		wantErr bool
+	}{
+		{name: "uint256", typ: "uint256", s: "12345", want: big.NewInt(12345)},
+		{name: "uint256_hex", typ: "uint256", s: "0xff", want: big.NewInt(255)},
+		{name: "uint256_sci", typ: "uint256", s: "1.5e3", want: big.NewInt(1500)},
+		{name: "uint8", typ: "uint8", s: "255", want: uint8(255)},
+		{name: "int8_min", typ: "int8", s: "-128", want: int8(-128)},
+		{name: "int24", typ: "int24", s: "-8388608", want: big.NewInt(-8388608)},
+		{name: "fixed", typ: "fixed128x18", s: "1.25", want: big.NewInt(1250000000000000000)},
+		{name: "bool", typ: "bool", s: "true", want: true},
+		{name: "string", typ: "string", s: " a, b ", want: " a, b "},
+		{name: "bytes", typ: "bytes", s: "0xabcd", want: []byte{0xab, 0xcd}},
+		{name: "bytes4", typ: "bytes4", s: "0xa9059cbb", want: [4]byte{0xa9, 0x05, 0x9c, 0xbb}},
+		{
+			name: "address",
+			typ:  "address",
+			s:    "0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C",
+			want: common.HexToAddress("0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C"),
+		},
+		{
+			name: "address_lowercase",
+			typ:  "address",
+			s:    "0x5a5b644fb1a3ca046317fe82bc695fff7bacf30c",
+			want: common.HexToAddress("0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C"),
+		},
+		{name: "uint_array", typ: "uint8[2]", s: "[1, 0x2]", want: [2]uint8{1, 2}},
+		{name: "string_slice", typ: "string[]", s: `["a, b", c]`, want: []string{"a, b", "c"}},
+		{name: "empty_slice", typ: "bool[]", s: "[]", want: []bool{}},
+		{name: "uint8_overflow", typ: "uint8", s: "256", wantErr: true},
+		{name: "int8_overflow", typ: "int8", s: "128", wantErr: true},
+		{name: "uint_negative", typ: "uint256", s: "-1", wantErr: true},
+		{name: "uint_fraction", typ: "uint256", s: "1.5", wantErr: true},
+		{name: "fixed_decimals", typ: "fixed128x2", s: "1.255", wantErr: true},
+		{name: "number", typ: "uint256", s: "12abc", wantErr: true},
+		{name: "bool_invalid", typ: "bool", s: "yes", wantErr: true},
+		{name: "address_checksum", typ: "address", s: "0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30c", wantErr: true},
+		{name: "address_short", typ: "address", s: "0x5a5b", wantErr: true},
+		{name: "bytes4_length", typ: "bytes4", s: "0xa9059c", wantErr: true},
+		{name: "bytes_no_prefix", typ: "bytes", s: "abcd", wantErr: true},
+		{name: "array_length", typ: "uint8[2]", s: "[1]", wantErr: true},
+		{name: "array_unbalanced", typ: "uint8[][]", s: "[[1]", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseArgValue(mustParseArgType(tt.typ), tt.s)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseArgValue() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseArgValue() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFuncSig_PackInput(t *testing.T) {
+	tests := []struct {
+		name string
+		sig  string
+		args []string
+		want string
+	}{
+		{
+			name: "erc20/transfer",
+			sig:  "transfer(address to, uint256 amount)",
+			args: []string{"0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C", "12496e18"},
+			want: "a9059cbb" +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"0000000000000000000000000000000000000000000002a568d6215ac1400000",
+		},
+		{
+			name: "tuple_array",
+			sig:  "swap((address token, uint256 amount)[] legs, bytes data)",
+			args: []string{"[(0x5a5b644fb1a3ca046317fe82bc695fff7bacf30c, 42)]", "0xabcd"},
+			want: hex.EncodeToString(evmtools.MethodID("swap((address,uint256)[],bytes)")) +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"00000000000000000000000000000000000000000000000000000000000000a0" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"abcd000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "underscored_names",
+			sig:  "f((address _, uint256 $amount) p, uint256 _)",
+			args: []string{"(0x5a5b644fb1a3ca046317fe82bc695fff7bacf30c, 42)", "7"},
+			want: hex.EncodeToString(evmtools.MethodID("f((address,uint256),uint256)")) +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a" +
+				"0000000000000000000000000000000000000000000000000000000000000007",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsig, err := NewFuncSignatureFromString(tt.sig)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			args, err := ParseArgValues(fsig.Inputs(), tt.args)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := fsig.PackInput(args...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if hex.EncodeToString(got) != tt.want {
+				t.Errorf("FuncSig.PackInput() = %x, want %s", got, tt.want)
+			}
+
+			unpacked, err := fsig.UnpackInput(got)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(unpacked) != len(args) {
+				t.Errorf("FuncSig.UnpackInput() = %v, want %d values", unpacked, len(args))
+			}
+		})
+	}
+}
+
+func TestFuncSig_PackOutput(t *testing.T) {
+	fsig, err := NewFuncSignatureFromString("balanceOf(address)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	args, err := ParseArgValues(fsig.Outputs(), []string{"1e18"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fsig.PackOutput(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := "0000000000000000000000000000000000000000000000000de0b6b3a7640000"; hex.EncodeToString(got) != want {
+		t.Errorf("FuncSig.PackOutput() = %x, want %s", got, want)
+	}
+
+	if _, err := ParseArgValues(fsig.Outputs(), nil); err == nil {
+		t.Error("ParseArgValues() with missing arguments succeeded")
+	}
+}
@@ -0,0 +1,416 @@
+package evmfuncs
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/kirillDanshin/evmtools"
+)
+
+// SelectorResolver resolves 4-byte function selectors into text signatures.
+// It has the same method as evmdis.SignatureResolver, so any evmdis resolver can be used.
+//
+// The results are ranked by age, see ScoreSignature, only if the resolver has a NewestFirst() bool method
+// returning true, like evmdis.OrderedSignatureResolver.
+type SelectorResolver interface {
+	// Lookup returns the known text signatures for the given 4-byte selector.
+	// An empty result with nil error means that the selector is unknown to the resolver.
+	Lookup(ctx context.Context, selector []byte) ([]string, error)
+}
+
+// DecodedArg is a decoded argument of a function call.
+type DecodedArg struct {
+	// Name is the parameter name, or "argN" for the unnamed N-th parameter
+	Name string
+
+	Type ArgType
+
+	// Value is the Go value of the go-ethereum ABI type, e.g. *big.Int for uint256
+	Value interface{}
+}
+
+// DecodedCall is calldata decoded with one of the candidate signatures of its selector.
+type DecodedCall struct {
+	Signature FuncSignature
+	Args      []DecodedArg
+
+	// Score is the ranking score of the signature, higher is better.
+	Score int
+
+	// Nested are the calls embedded in the arguments, see RegisterWrapper.
+	Nested []NestedCall
+}
+
+// scoreWrapper is the ranking bonus of the registered wrappers among the candidates decoded by CalldataDecoder.
+// It's kept out of ScoreSignature, so that registering a wrapper doesn't change the ranking elsewhere.
+const scoreWrapper = ScoreWellKnown / 2
+
+// DefaultMaxCallDepth is the default nesting depth of the calls decoded by CalldataDecoder.
+const DefaultMaxCallDepth = 4
+
+// CalldataDecoder decodes calldata of unknown functions.
+type CalldataDecoder struct {
+	resolver SelectorResolver
+	maxDepth int
+}
+
+// DecoderOption configures a CalldataDecoder.
+type DecoderOption func(*CalldataDecoder)
+
+// WithSelectorResolver sets the resolver used to look up signatures of the selectors
+// missing in the well-known function dictionary.
+func WithSelectorResolver(r SelectorResolver) DecoderOption {
+	return func(d *CalldataDecoder) {
+		d.resolver = r
+	}
+}
+
+// WithMaxCallDepth limits the nesting depth of the decoded calls, DefaultMaxCallDepth by default.
+// Zero disables decoding of the nested calls.
+func WithMaxCallDepth(n int) DecoderOption {
+	return func(d *CalldataDecoder) {
+		d.maxDepth = n
+	}
+}
+
+func NewCalldataDecoder(opts ...DecoderOption) *CalldataDecoder {
+	d := &CalldataDecoder{
+		maxDepth: DefaultMaxCallDepth,
+	}
+	for _, opt := range opts {
+		opt(d)
+	}
+
+	return d
+}
+
+// DecodeCalldata decodes the calldata using the well-known function dictionary only,
+// see CalldataDecoder.Decode.
+func DecodeCalldata(data []byte) ([]DecodedCall, error) {
+	return NewCalldataDecoder().Decode(context.Background(), data)
+}
+
+// Decode looks up the candidate signatures of the calldata selector in the well-known function dictionary,
+// the registered wrappers and the configured resolver, and returns the calls decoded with the candidates,
+// best ranked first.
+//
+// The candidates are only kept if the arguments are encoded canonically: the data is consumed exactly,
+// the values are padded with zeros (or sign-extended), the booleans are 0 or 1, and the dynamic values
+// follow each other in order. An empty result with nil error means that no candidate decodes the calldata.
+//
+// The calls embedded in the arguments are decoded recursively into DecodedCall.Nested,
+// see RegisterWrapper, up to the depth set by WithMaxCallDepth.
+func (d *CalldataDecoder) Decode(ctx context.Context, data []byte) ([]DecodedCall, error) {
+	return d.decode(ctx, data, 0)
+}
+
+func (d *CalldataDecoder) decode(ctx context.Context, data []byte, depth int) ([]DecodedCall, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("evmfuncs: calldata is %d bytes, expected at least 4", len(data))
+	}
+
+	selector := data[:4]
+
+	type candidate struct {
+		fsig    *FuncSig
+		age     int
+		wrapper bool
+	}
+
+	var candidates []candidate
+	if desc, ok := GetWellKnownFuncByMethodID(selector); ok {
+		candidates = append(candidates, candidate{
+			fsig: &FuncSig{
+				name:         desc.name,
+				inputs:       desc.inputs,
+				outputs:      desc.outputs,
+				unescapedSel: desc.knownMethodKey,
+			},
+			age: -1,
+		})
+	}
+
+	for _, w := range wrappersBySelector(selector) {
+		candidates = append(candidates, candidate{fsig: w.fsig, age: -1, wrapper: true})
+	}
+
+	var resolveErr error
+	if d.resolver != nil {
+		sigs, err := d.resolver.Lookup(ctx, selector)
+		if err != nil {
+			resolveErr = err
+		}
+
+		ordered, ok := d.resolver.(interface{ NewestFirst() bool })
+		for i, sig := range sigs {
+			fsig, err := parseFuncSig(sig)
+			if err != nil {
+				continue
+			}
+
+			age := -1
+			if ok && ordered.NewestFirst() {
+				age = i
+			}
+			candidates = append(candidates, candidate{fsig: fsig, age: age})
+		}
+	}
+
+	var calls []DecodedCall
+	seen := map[string]struct{}{}
+	for _, c := range candidates {
+		sig := c.fsig.String()
+		if _, ok := seen[sig]; ok || !bytes.Equal(evmtools.MethodID(sig), selector) {
+			continue
+		}
+		seen[sig] = struct{}{}
+
+		args, err := decodeArgs(c.fsig.Inputs(), data[4:])
+		if err != nil {
+			continue
+		}
+
+		score := ScoreSignature(selector, sig, c.age)
+		if c.wrapper {
+			score += scoreWrapper
+		}
+
+		calls = append(calls, DecodedCall{Signature: c.fsig, Args: args, Score: score})
+	}
+
+	if len(calls) == 0 && resolveErr != nil {
+		return nil, resolveErr
+	}
+
+	sort.SliceStable(calls, func(i, j int) bool {
+		return calls[i].Score > calls[j].Score
+	})
+
+	for i := range calls {
+		d.expand(ctx, &calls[i], depth)
+	}
+
+	return calls, nil
+}
+
+// decodeArgs decodes the canonically encoded arguments of the parameters.
+func decodeArgs(params []FuncParam, data []byte) ([]DecodedArg, error) {
+	types := make([]ArgType, len(params))
+	for i, p := range params {
+		types[i] = p.Type
+	}
+
+	size, err := checkTupleEncoding(types, data, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	if size != len(data) {
+		return nil, fmt.Errorf("evmfuncs: %d trailing bytes", len(data)-size)
+	}
+
+	abiArgs, err := abiArguments(params)
+	if err != nil {
+		return nil, err
+	}
+
+	values, err := abiArgs.UnpackValues(data)
+	if err != nil {
+		return nil, err
+	}
+
+	args := make([]DecodedArg, len(params))
+	for i, p := range params {
+		name := p.Name
+		if name == "" {
+			name = fmt.Sprintf("arg%d", i)
+		}
+
+		args[i] = DecodedArg{Name: name, Type: p.Type, Value: values[i]}
+	}
+
+	return args, nil
+}
+
+var errShortData = errors.New("evmfuncs: data too short")
+
+// checkTupleEncoding checks the canonical encoding of the values of the types starting at data[start:],
+// and returns its size: the heads of the values, followed by the tails of the dynamic values in order.
+// The offsets of the dynamic values are relative to the start.
+func checkTupleEncoding(types []ArgType, data []byte, start int) (int, error) {
+	return checkSequenceEncoding(len(types), func(i int) ArgType { return types[i] }, data, start)
+}
+
+// checkArrayEncoding checks the canonical encoding of n values of the type starting at data[start:],
+// see checkTupleEncoding.
+func checkArrayEncoding(elem ArgType, n int, data []byte, start int) (int, error) {
+	head := elem.HeadSize()
+	if head == 0 {
+		// the arrays of empty tuples take no space
+		return 0, nil
+	}
+
+	if n > (len(data)-start)/head {
+		return 0, errShortData
+	}
+
+	return checkSequenceEncoding(n, func(int) ArgType { return elem }, data, start)
+}
+
+// checkSequenceEncoding checks the canonical encoding of the n values of the types typeAt(i)
+// starting at data[start:], see checkTupleEncoding.
+func checkSequenceEncoding(n int, typeAt func(i int) ArgType, data []byte, start int) (int, error) {
+	head := 0
+	for i := 0; i < n; i++ {
+		size := typeAt(i).HeadSize()
+		if size > len(data)-start-head {
+			return 0, errShortData
+		}
+		head += size
+	}
+
+	pos, tail := start, start+head
+	for i := 0; i < n; i++ {
+		t := typeAt(i)
+		if !t.IsDynamic() {
+			if _, err := checkValueEncoding(t, data, pos); err != nil {
+				return 0, err
+			}
+
+			pos += t.HeadSize()
+			continue
+		}
+
+		offset, err := readLength(data, pos)
+		if err != nil {
+			return 0, err
+		}
+
+		if start+offset != tail {
+			return 0, fmt.Errorf("evmfuncs: non-canonical offset %d at %d, expected %d", offset, pos, tail-start)
+		}
+
+		size, err := checkValueEncoding(t, data, tail)
+		if err != nil {
+			return 0, err
+		}
+
+		pos += 32
+		tail += size
+	}
+
+	return tail - start, nil
+}
+
+// checkValueEncoding checks the canonical encoding of the value of the type at data[start:], and returns its size.
+func checkValueEncoding(t ArgType, data []byte, start int) (int, error) {
+	switch t.Kind {
+	case ArgKindBytes, ArgKindString:
+		n, err := readLength(data, start)
+		if err != nil {
+			return 0, err
+		}
+
+		padded := (n + 31) / 32 * 32
+		if padded > len(data)-start-32 {
+			return 0, errShortData
+		}
+
+		if !isZero(data[start+32+n : start+32+padded]) {
+			return 0, fmt.Errorf("evmfuncs: non-zero %s padding at %d", t, start)
+		}
+
+		return 32 + padded, nil
+
+	case ArgKindSlice:
+		n, err := readLength(data, start)
+		if err != nil {
+			return 0, err
+		}
+
+		size, err := checkArrayEncoding(*t.Elem, n, data, start+32)
+		if err != nil {
+			return 0, err
+		}
+
+		return 32 + size, nil
+
+	case ArgKindArray:
+		return checkArrayEncoding(*t.Elem, t.Size, data, start)
+
+	case ArgKindTuple:
+		types := make([]ArgType, len(t.Components))
+		for i, c := range t.Components {
+			types[i] = c.Type
+		}
+
+		return checkTupleEncoding(types, data, start)
+	}
+
+	if len(data)-start < 32 {
+		return 0, errShortData
+	}
+
+	if !isCanonicalWord(t, data[start:start+32]) {
+		return 0, fmt.Errorf("evmfuncs: non-canonical %s at %d", t, start)
+	}
+
+	return 32, nil
+}
+
+// isCanonicalWord returns true if the word is a valid encoding of the elementary type.
+func isCanonicalWord(t ArgType, word []byte) bool {
+	switch t.Kind {
+	case ArgKindUint, ArgKindUfixed:
+		return isZero(word[:32-t.Size/8])
+	case ArgKindInt, ArgKindFixed:
+		if t.Size == 256 {
+			return true
+		}
+
+		pad := word[:32-t.Size/8]
+		if word[32-t.Size/8]&0x80 == 0 {
+			return isZero(pad)
+		}
+
+		return bytes.Count(pad, []byte{0xff}) == len(pad)
+	case ArgKindAddress:
+		return isZero(word[:12])
+	case ArgKindBool:
+		return isZero(word[:31]) && word[31] <= 1
+	case ArgKindFixedBytes:
+		return isZero(word[t.Size:])
+	case ArgKindFunction:
+		return isZero(word[24:])
+	}
+
+	return false
+}
+
+// readLength reads the word at data[start:] as a length or an offset, which can't exceed the data length.
+func readLength(data []byte, start int) (int, error) {
+	if len(data)-start < 32 {
+		return 0, errShortData
+	}
+
+	n := new(big.Int).SetBytes(data[start : start+32])
+	if !n.IsInt64() || n.Int64() > int64(len(data)) {
+		return 0, fmt.Errorf("evmfuncs: invalid length %s at %d", n, start)
+	}
+
+	return int(n.Int64()), nil
+}
+
+func isZero(b []byte) bool {
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,276 @@
+package evmfuncs
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/kirillDanshin/evmtools"
+)
+
+type staticResolver map[string][]string
+
+func (r staticResolver) Lookup(_ context.Context, selector []byte) ([]string, error) {
+	return r[hex.EncodeToString(selector)], nil
+}
+
+// orderedResolver returns the signatures newest first.
+type orderedResolver struct {
+	staticResolver
+}
+
+func (orderedResolver) NewestFirst() bool {
+	return true
+}
+
+type failingResolver struct{}
+
+func (failingResolver) Lookup(context.Context, []byte) ([]string, error) {
+	return nil, errors.New("lookup failed")
+}
+
+func mustDecodeHex(t *testing.T, words ...string) []byte {
+	data, err := hex.DecodeString(strings.Join(words, ""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+func TestDecodeCalldata(t *testing.T) {
+	transfer := mustDecodeHex(t,
+		"a9059cbb",
+		"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c",
+		"0000000000000000000000000000000000000000000002a568d6215ac1400000")
+
+	calls, err := DecodeCalldata(transfer)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(calls) != 1 {
+		t.Fatalf("DecodeCalldata() = %d calls, want 1", len(calls))
+	}
+
+	call := calls[0]
+	if got := call.Signature.String(); got != "transfer(address,uint256)" {
+		t.Errorf("DecodeCalldata() signature = %s", got)
+	}
+	if call.Score < ScoreWellKnown {
+		t.Errorf("DecodeCalldata() score = %d, want well-known", call.Score)
+	}
+	if len(call.Args) != 2 || call.Args[0].Name != "to" || call.Args[1].Name != "amount" {
+		t.Fatalf("DecodeCalldata() args = %+v", call.Args)
+	}
+	if call.Args[0].Value != common.HexToAddress("0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C") {
+		t.Errorf("DecodeCalldata() to = %v", call.Args[0].Value)
+	}
+	if want, _ := new(big.Int).SetString("12496000000000000000000", 10); call.Args[1].Value.(*big.Int).Cmp(want) != 0 {
+		t.Errorf("DecodeCalldata() amount = %v, want %v", call.Args[1].Value, want)
+	}
+
+	if _, err := DecodeCalldata([]byte{0xa9, 0x05}); err == nil {
+		t.Error("DecodeCalldata() of 2 bytes succeeded")
+	}
+}
+
+func TestCalldataDecoder_Decode(t *testing.T) {
+	const swapSig = "swap((address,uint256)[],bytes)"
+
+	swapID := hex.EncodeToString(evmtools.MethodID(swapSig))
+	swap := swapID +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+		"000000000000000000000000000000000000000000000000000000000000002a" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"abcd000000000000000000000000000000000000000000000000000000000000"
+
+	underscoredID := hex.EncodeToString(evmtools.MethodID("f((address,uint256),uint256)"))
+
+	resolver := staticResolver{
+		underscoredID: {"f((address _, uint256 $amount) p, uint256 _)"},
+		swapID: {
+			"swap_tg_abcdef_(uint256)",
+			"swap(tuple(address token, uint amount)[] legs, bytes data)",
+			"swap((address,uint256)[],bytes)",
+		},
+		"a9059cbb": {"many_msg_babbage(bytes1)", "transfer(address,uint256)"},
+		"2e1a7d4d": {"withdraw(uint256)"},
+		"d0e30db0": {"deposit()"},
+		"ffffffff": {"LOCK8605463013()", "test266151307()"},
+	}
+
+	tests := []struct {
+		name     string
+		resolver SelectorResolver
+		data     string
+		want     []string
+		wantErr  bool
+	}{
+		{
+			name: "tuple_array",
+			data: swap,
+			want: []string{swapSig},
+		},
+		{
+			name: "underscored_names",
+			data: underscoredID +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a" +
+				"0000000000000000000000000000000000000000000000000000000000000007",
+			want: []string{"f((address,uint256),uint256)"},
+		},
+		{
+			name: "well_known_first",
+			data: "a9059cbb" +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a",
+			want: []string{"transfer(address,uint256)"},
+		},
+		{
+			name: "no_args",
+			data: "d0e30db0",
+			want: []string{"deposit()"},
+		},
+		{
+			name: "uint256_high_bits",
+			data: "2e1a7d4d" + "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffd6",
+			want: []string{"withdraw(uint256)"},
+		},
+		{
+			name: "trailing_bytes",
+			data: "2e1a7d4d" + "000000000000000000000000000000000000000000000000000000000000002a" + "00",
+		},
+		{
+			name: "short",
+			data: "2e1a7d4d" + "0000000000000000000000000000000000000000000000000000002a",
+		},
+		{
+			name: "dirty_address",
+			data: "a9059cbb" +
+				"0000000000000000000000ff5a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a",
+		},
+		{
+			name: "non_canonical_offset",
+			data: swapID +
+				"0000000000000000000000000000000000000000000000000000000000000060" +
+				"00000000000000000000000000000000000000000000000000000000000000c0" +
+				"0000000000000000000000000000000000000000000000000000000000000000" +
+				"0000000000000000000000000000000000000000000000000000000000000001" +
+				"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+				"000000000000000000000000000000000000000000000000000000000000002a" +
+				"0000000000000000000000000000000000000000000000000000000000000002" +
+				"abcd000000000000000000000000000000000000000000000000000000000000",
+		},
+		{
+			name: "dirty_bytes_padding",
+			data: swap[:len(swap)-2] + "01",
+		},
+		{
+			name: "huge_length",
+			data: swapID +
+				"0000000000000000000000000000000000000000000000000000000000000040" +
+				"00000000000000000000000000000000000000000000000000000000000000a0" +
+				"00000000000000000000000000000000000000000000000000000000ffffffff",
+		},
+		{
+			name: "unordered_resolver",
+			data: "ffffffff",
+			want: []string{"LOCK8605463013()", "test266151307()"},
+		},
+		{
+			name:     "oldest_preferred",
+			resolver: orderedResolver{resolver},
+			data:     "ffffffff",
+			want:     []string{"test266151307()", "LOCK8605463013()"},
+		},
+		{
+			name:     "resolver_error",
+			resolver: failingResolver{},
+			data:     "2e1a7d4d" + "000000000000000000000000000000000000000000000000000000000000002a",
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := tt.resolver
+			if r == nil {
+				r = resolver
+			}
+
+			calls, err := NewCalldataDecoder(WithSelectorResolver(r)).Decode(context.Background(), mustDecodeHex(t, tt.data))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("CalldataDecoder.Decode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			var got []string
+			for _, call := range calls {
+				got = append(got, call.Signature.String())
+			}
+
+			if strings.Join(got, " ") != strings.Join(tt.want, " ") {
+				t.Errorf("CalldataDecoder.Decode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckValueEncoding(t *testing.T) {
+	tests := []struct {
+		typ  string
+		word string
+		want bool
+	}{
+		{typ: "int8", word: "ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", want: true},
+		{typ: "int8", word: "000000000000000000000000000000000000000000000000000000000000007f", want: true},
+		{typ: "int8", word: "0000000000000000000000000000000000000000000000000000000000000080", want: false},
+		{typ: "int8", word: "00ffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff80", want: false},
+		{typ: "uint8", word: "00000000000000000000000000000000000000000000000000000000000001ff", want: false},
+		{typ: "bool", word: "0000000000000000000000000000000000000000000000000000000000000002", want: false},
+		{typ: "bytes4", word: "a9059cbb00000000000000000000000000000000000000000000000000000000", want: true},
+		{typ: "bytes4", word: "a9059cbb00000000000000000000000000000000000000000000000000000001", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.typ+"/"+tt.word[60:], func(t *testing.T) {
+			_, err := checkValueEncoding(mustParseArgType(tt.typ), mustDecodeHex(t, tt.word), 0)
+			if got := err == nil; got != tt.want {
+				t.Errorf("checkValueEncoding() error = %v, want valid %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestCheckValueEncoding_HugeArrays(t *testing.T) {
+	data := mustDecodeHex(t, strings.Repeat("0000000000000000000000000000000000000000000000000000000000000020", 4))
+
+	tests := []struct {
+		name string
+		typ  ArgType
+	}{
+		{name: "dynamic_elems", typ: ArrayType(ArgTypeString, 1e12)},
+		{name: "static_elems", typ: ArrayType(ArgTypeUint, 1<<59)},
+		{name: "nested", typ: ArrayType(ArrayType(ArgTypeUint, 1<<32), 1<<32)},
+		{name: "max_int", typ: ArrayType(ArgTypeUint, math.MaxInt64)},
+		{name: "tuple", typ: TupleType(FuncParam{Type: ArrayType(ArgTypeUint, 1<<32)}, FuncParam{Type: ArgTypeBool})},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := checkValueEncoding(tt.typ, data, 0); !errors.Is(err, errShortData) {
+				t.Errorf("checkValueEncoding() error = %v, want %v", err, errShortData)
+			}
+		})
+	}
+
+	if size, err := checkValueEncoding(ArrayType(ArrayType(TupleType(), 1<<32), 1<<32), data, 0); size != 0 || err != nil {
+		t.Errorf("checkValueEncoding() of empty tuples = %d, %v, want 0, nil", size, err)
+	}
+}
@@ -8,9 +8,18 @@ import (
 	"github.com/kirillDanshin/evmtools"
 )
 
+// FuncParam is a function parameter, or a component of a tuple parameter.
 type FuncParam struct {
 	Name string
-	Type string
+
+	Type ArgType
+
+	// Indexed is true for the indexed event parameters
+	Indexed bool
+
+	// Location is the data location of the parameter, LocationMemory, LocationCalldata,
+	// LocationStorage, or empty if not specified
+	Location string
 }
 
 type FuncSig struct {
@@ -58,6 +67,14 @@ type FuncSignature interface {
 	// UnpackOutput upacks values from given function data and returns a list of Go values.
 	// The function signature must match the function data.
 	UnpackOutput(data []byte) ([]interface{}, error)
+
+	// PackInput encodes the function call data: the method ID followed by the encoded arguments.
+	// The arguments are Go values of the go-ethereum ABI types, e.g. *big.Int for uint256,
+	// see ParseArgValues to convert them from strings.
+	PackInput(args ...interface{}) ([]byte, error)
+
+	// PackOutput encodes the values returned by the function.
+	PackOutput(args ...interface{}) ([]byte, error)
 }
 
 func (fsig *FuncSig) Name() string {
@@ -112,7 +129,7 @@ func (fsig *FuncSig) lookupWellKnownKey() string {
 func (fsig *FuncSig) removeParamNamesFromSelector() string {
 	out := fsig.name + "("
 	for i, param := range fsig.Inputs() {
-		out += param.Type
+		out += param.Type.String()
 		if i < len(fsig.Inputs())-1 {
 			out += ","
 		}
@@ -127,7 +144,7 @@ func (fsig *FuncSig) String() string {
 
 	sig += "("
 	for i, input := range fsig.Inputs() {
-		sig += input.Type
+		sig += input.Type.String()
 		if i < len(fsig.Inputs())-1 {
 			sig += ","
 		}
@@ -142,7 +159,7 @@ func (fsig *FuncSig) Describe() string {
 
 	desc += "("
 	for i, input := range fsig.Inputs() {
-		desc += input.Type + " " + input.Name
+		desc += input.Type.String() + " " + input.Name
 		if i < len(fsig.Inputs())-1 {
 			desc += ","
 		}
@@ -152,7 +169,7 @@ func (fsig *FuncSig) Describe() string {
 	if len(outs) > 0 {
 		desc += " returns ("
 		for i, output := range fsig.Outputs() {
-			desc += output.Type + " " + output.Name
+			desc += output.Type.String() + " " + output.Name
 			if i < len(fsig.Outputs())-1 {
 				desc += ","
 			}
@@ -220,36 +237,39 @@ func (fsig *FuncSig) UnpackOutput(data []byte) ([]interface{}, error) {
 	return fsig.unpackArgs(fsigABI.Methods[fsig.Name()].Outputs, data, false)
 }
 
-// ABI creates a fake ABI object for the function signature
-func (fsig *FuncSig) ABI() (*abi.ABI, error) {
-	abiInputs := abi.Arguments{}
+func (fsig *FuncSig) PackInput(args ...interface{}) ([]byte, error) {
+	abiInputs, err := abiArguments(fsig.Inputs())
+	if err != nil {
+		return nil, err
+	}
 
-	for _, param := range fsig.inputs {
-		abiType, err := abi.NewType(param.Type, "", nil)
-		if err != nil {
-			return nil, err
-		}
+	data, err := abiInputs.Pack(args...)
+	if err != nil {
+		return nil, err
+	}
 
-		abiInputs = append(abiInputs, abi.Argument{
-			Name:    param.Name,
-			Type:    abiType,
-			Indexed: false,
-		})
+	return append(evmtools.MethodID(fsig.removeParamNamesFromSelector()), data...), nil
+}
+
+func (fsig *FuncSig) PackOutput(args ...interface{}) ([]byte, error) {
+	abiOutputs, err := abiArguments(fsig.Outputs())
+	if err != nil {
+		return nil, err
 	}
 
-	abiOutputs := abi.Arguments{}
+	return abiOutputs.Pack(args...)
+}
 
-	for _, param := range fsig.outputs {
-		abiType, err := abi.NewType(param.Type, "", nil)
-		if err != nil {
-			return nil, err
-		}
+// ABI creates a fake ABI object for the function signature
+func (fsig *FuncSig) ABI() (*abi.ABI, error) {
+	abiInputs, err := abiArguments(fsig.inputs)
+	if err != nil {
+		return nil, err
+	}
 
-		abiOutputs = append(abiOutputs, abi.Argument{
-			Name:    param.Name,
-			Type:    abiType,
-			Indexed: false,
-		})
+	abiOutputs, err := abiArguments(fsig.outputs)
+	if err != nil {
+		return nil, err
 	}
 
 	mutability := ""
@@ -266,22 +286,41 @@ func (fsig *FuncSig) ABI() (*abi.ABI, error) {
 	}, nil
 }
 
-// parseFuncSig parses a function signature string and returns a FuncSig object
-func parseFuncSig(rawSig string) *FuncSig {
-	name, sig := parseName(rawSig)
+func abiArguments(params []FuncParam) (abi.Arguments, error) {
+	args := abi.Arguments{}
+
+	for _, param := range params {
+		abiType, err := param.Type.ABIType()
+		if err != nil {
+			return nil, err
+		}
 
-	inputs, sig := parseInputs(sig)
+		args = append(args, abi.Argument{
+			Name:    param.Name,
+			Type:    abiType,
+			Indexed: param.Indexed,
+		})
+	}
 
-	outputs, _ := parseOutputs(sig)
+	return args, nil
+}
+
+// parseFuncSig parses a function signature string and returns a FuncSig object
+func parseFuncSig(rawSig string) (*FuncSig, error) {
+	name, inputs, outputs, err := parseSignature(rawSig)
+	if err != nil {
+		return nil, err
+	}
 
 	return &FuncSig{
 		name:         name,
 		inputs:       inputs,
 		outputs:      outputs,
 		unescapedSel: rawSig,
-	}
+	}, nil
 }
 
+// parseName splits the signature into the function name and the rest of the signature, starting with "(".
 func parseName(sig string) (string, string) {
 	var name string
 	if strings.Contains(sig, "(") {
@@ -295,64 +334,6 @@ func parseName(sig string) (string, string) {
 	return name, sig
 }
 
-func parseInputs(sig string) ([]FuncParam, string) {
-	var inputs []FuncParam
-
-	if strings.Contains(sig, "(") {
-		sig = sig[strings.Index(sig, "(")+1:]
-		if strings.Contains(sig, ")") {
-			sig = sig[:strings.Index(sig, ")")]
-		}
-		if sig != "" {
-			inputs = parseParams(sig)
-		}
-	}
-
-	return inputs, sig
-}
-
-func parseOutputs(sig string) ([]FuncParam, string) {
-	var outputs []FuncParam
-
-	if strings.Contains(sig, "returns") {
-		sig = sig[strings.Index(sig, "returns")+7:]
-		if strings.Contains(sig, ")") {
-			sig = sig[:strings.Index(sig, ")")]
-		}
-		if sig != "" {
-			outputs = parseParams(sig)
-		}
-	}
-
-	return outputs, sig
-}
-
-func parseParams(sig string) []FuncParam {
-	var params []FuncParam
-
-	paramStrs := strings.Split(sig, ",")
-	for _, paramStr := range paramStrs {
-		paramStr = strings.TrimSpace(paramStr)
-		param := parseParam(paramStr)
-		params = append(params, param)
-	}
-
-	return params
-}
-
-func parseParam(paramStr string) FuncParam {
-	var param FuncParam
-
-	if strings.Contains(paramStr, " ") {
-		param.Type = paramStr[:strings.Index(paramStr, " ")]
-		param.Name = paramStr[strings.Index(paramStr, " ")+1:]
-	} else {
-		param.Type = paramStr
-	}
-
-	return param
-}
-
 func NewFuncSignatureFromString(sig string) (FuncSignature, error) {
 	if wellKnown, ok := GetWellKnownFuncBySig(sig); ok {
 		return &FuncSig{
@@ -363,9 +344,5 @@ func NewFuncSignatureFromString(sig string) (FuncSignature, error) {
 		}, nil
 	}
 
-	fsig := parseFuncSig(sig)
-
-	fsig.unescapedSel = sig
-
-	return fsig, nil
+	return parseFuncSig(sig)
 }
@@ -20,7 +20,7 @@ func init() {
 
 		sig := v.name + "("
 		for i, p := range v.inputs {
-			sig += p.Type
+			sig += p.Type.String()
 			if i < len(v.inputs)-1 {
 				sig += ","
 			}
@@ -80,6 +80,19 @@ func (desc *WellKnownFuncDesc) Effects() Effect {
 	return desc.effects
 }
 
+// Signature returns the canonical signature of the function, e.g. "transfer(address,uint256)"
+func (desc *WellKnownFuncDesc) Signature() string {
+	sig := desc.name + "("
+	for i, p := range desc.inputs {
+		sig += p.Type.String()
+		if i < len(desc.inputs)-1 {
+			sig += ","
+		}
+	}
+
+	return sig + ")"
+}
+
 func (desc *WellKnownFuncDesc) MethodIDHex() string {
 	return desc.methodIDHex
 }
@@ -87,17 +100,7 @@ func (desc *WellKnownFuncDesc) MethodIDHex() string {
 func (desc *WellKnownFuncDesc) MethodID() []byte {
 	mID, err := hex.DecodeString(desc.methodIDHex)
 	if err != nil {
-		sel := desc.name + "("
-		for i, p := range desc.inputs {
-			sel += p.Type
-			if i < len(desc.inputs)-1 {
-				sel += ","
-			}
-		}
-
-		sel += ")"
-
-		return evmtools.MethodID(sel)
+		return evmtools.MethodID(desc.Signature())
 	}
 
 	return mID
@@ -135,13 +138,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "accountAddress",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "balance",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		effects: EffectRead,
@@ -152,11 +155,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "receiverAddress",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -169,7 +172,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -182,11 +185,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "accountAddress",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 	},
@@ -197,11 +200,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -213,11 +216,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "tokenID",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -229,11 +232,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "value",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -245,11 +248,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -261,15 +264,15 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "from",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -281,11 +284,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "spender",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "amount",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -298,7 +301,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "name",
-				Type: "string",
+				Type: ArgTypeString,
 			},
 		},
 		effects: EffectRead,
@@ -310,7 +313,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "owner",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		effects: EffectRead,
@@ -322,7 +325,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "symbol",
-				Type: "string",
+				Type: ArgTypeString,
 			},
 		},
 		effects: EffectRead,
@@ -334,7 +337,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "totalSupply",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		effects: EffectRead,
@@ -346,7 +349,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "decimals",
-				Type: "uint8",
+				Type: UintType(8),
 			},
 		},
 		effects: EffectRead,
@@ -357,13 +360,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "accountAddress",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "balance",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		effects: EffectRead,
@@ -374,17 +377,17 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "owner",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "spender",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "allowance",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		effects: EffectRead,
@@ -402,7 +405,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "newOwner",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		outputs: []FuncParam{},
@@ -429,7 +432,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		outputs: []FuncParam{
 			{
 				Name: "paused",
-				Type: "bool",
+				Type: ArgTypeBool,
 			},
 		},
 		effects: EffectRead,
@@ -440,13 +443,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "owner",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		effects: EffectRead,
@@ -457,13 +460,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "operator",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		effects: EffectRead,
@@ -474,11 +477,11 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "operator",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "approved",
-				Type: "bool",
+				Type: ArgTypeBool,
 			},
 		},
 		outputs: []FuncParam{},
@@ -490,17 +493,17 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "owner",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "operator",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "approved",
-				Type: "bool",
+				Type: ArgTypeBool,
 			},
 		},
 		effects: EffectRead,
@@ -511,15 +514,15 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "from",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -532,19 +535,19 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "from",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "to",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 			{
 				Name: "data",
-				Type: "bytes",
+				Type: ArgTypeBytes,
 			},
 		},
 		outputs: []FuncParam{},
@@ -556,13 +559,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "tokenURI",
-				Type: "string",
+				Type: ArgTypeString,
 			},
 		},
 		effects: EffectRead,
@@ -573,13 +576,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "interfaceId",
-				Type: "bytes4",
+				Type: FixedBytesType(4),
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "supported",
-				Type: "bool",
+				Type: ArgTypeBool,
 			},
 		},
 		effects: EffectRead,
@@ -590,19 +593,19 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "operator",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "from",
-				Type: "address",
+				Type: ArgTypeAddress,
 			},
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 			{
 				Name: "data",
-				Type: "bytes",
+				Type: ArgTypeBytes,
 			},
 		},
 	},
@@ -613,13 +616,13 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{
 			{
 				Name: "onHold",
-				Type: "bool",
+				Type: ArgTypeBool,
 			},
 		},
 		effects: EffectRead,
@@ -630,7 +633,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -642,7 +645,7 @@ var funcDescriptions = map[string]*WellKnownFuncDesc{
 		inputs: []FuncParam{
 			{
 				Name: "tokenId",
-				Type: "uint256",
+				Type: ArgTypeUint,
 			},
 		},
 		outputs: []FuncParam{},
@@ -661,7 +664,7 @@ func getRBACFuncsForRole(role string) map[string]*WellKnownFuncDesc {
 			inputs: []FuncParam{
 				{
 					Name: "account",
-					Type: "address",
+					Type: ArgTypeAddress,
 				},
 			},
 			outputs: []FuncParam{},
@@ -673,7 +676,7 @@ func getRBACFuncsForRole(role string) map[string]*WellKnownFuncDesc {
 			inputs: []FuncParam{
 				{
 					Name: "account",
-					Type: "address",
+					Type: ArgTypeAddress,
 				},
 			},
 			outputs: []FuncParam{},
@@ -692,13 +695,13 @@ func getRBACFuncsForRole(role string) map[string]*WellKnownFuncDesc {
 			inputs: []FuncParam{
 				{
 					Name: "account",
-					Type: "address",
+					Type: ArgTypeAddress,
 				},
 			},
 			outputs: []FuncParam{
 				{
 					Name: "hasRole",
-					Type: "bool",
+					Type: ArgTypeBool,
 				},
 			},
 			effects: EffectRead,
@@ -710,7 +713,7 @@ func getRBACFuncsForRole(role string) map[string]*WellKnownFuncDesc {
 			outputs: []FuncParam{
 				{
 					Name: "is" + firstCap,
-					Type: "bool",
+					Type: ArgTypeBool,
 				},
 			},
 			effects: EffectRead,
@@ -721,13 +724,13 @@ func getRBACFuncsForRole(role string) map[string]*WellKnownFuncDesc {
 			inputs: []FuncParam{
 				{
 					Name: "account",
-					Type: "address",
+					Type: ArgTypeAddress,
 				},
 			},
 			outputs: []FuncParam{
 				{
 					Name: "is" + firstCap,
-					Type: "bool",
+					Type: ArgTypeBool,
 				},
 			},
 			effects: EffectRead,
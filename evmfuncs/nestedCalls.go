@@ -0,0 +1,404 @@
+package evmfuncs
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/kirillDanshin/evmtools"
+)
+
+// NestedCall is a call embedded in an argument of the enclosing call.
+type NestedCall struct {
+	// Path is the argument holding the calldata, e.g. "data" or "calls[1].callData"
+	Path string
+
+	// To is the target of the call, nil if the wrapper calls itself, e.g. multicall(bytes[]),
+	// or the target is unknown
+	To *common.Address
+
+	// Data is the calldata, or the command input of the wrappers with custom encodings
+	Data []byte
+
+	// Calls are the candidate decodings of the data, best ranked first, empty if unknown
+	Calls []DecodedCall
+}
+
+// Wrapper describes a function making the calls embedded in its arguments,
+// e.g. multicall(bytes[]) or Safe execTransaction.
+//
+// The arguments are addressed by paths of the parameter names: "data" is the data parameter,
+// "data[]" are the elements of the data array, and "calls[].callData" are the callData components
+// of the calls array of tuples.
+type Wrapper struct {
+	// Signature is the signature with the parameter names used by the paths,
+	// e.g. "execute(address dest, uint256 value, bytes func)"
+	Signature string
+
+	// Data is the path of the bytes holding the calldata
+	Data string
+
+	// To is the path of the address the calls are made to, empty if the wrapper calls itself.
+	// If it goes through an array, the addresses are paired with the calldata by position,
+	// e.g. "calls[].target" with "calls[].callData", or "dest[]" with "func[]".
+	To string
+
+	// Unwrap decodes the nested calls of the wrappers with custom encodings, e.g. the commands of
+	// Uniswap Universal Router. If it is set, Data and To are ignored.
+	Unwrap func(args []DecodedArg) []NestedCall
+
+	fsig *FuncSig
+}
+
+var (
+	wrappersMu sync.RWMutex
+	wrappers   = map[string]Wrapper{}
+)
+
+// RegisterWrapper adds the wrapper to the registry used by CalldataDecoder, replacing the registered wrapper
+// with the same canonical signature. The registered wrappers are also candidate signatures of their selectors.
+func RegisterWrapper(w Wrapper) error {
+	fsig, err := parseFuncSig(w.Signature)
+	if err != nil {
+		return err
+	}
+
+	if w.Unwrap == nil {
+		if err := checkPathType(fsig.inputs, w.Data, ArgKindBytes); err != nil {
+			return err
+		}
+
+		if w.To != "" {
+			if err := checkPathType(fsig.inputs, w.To, ArgKindAddress); err != nil {
+				return err
+			}
+		}
+	}
+	w.fsig = fsig
+
+	wrappersMu.Lock()
+	defer wrappersMu.Unlock()
+
+	wrappers[fsig.String()] = w
+
+	return nil
+}
+
+// LookupWrapper returns the registered wrapper with the given canonical signature.
+func LookupWrapper(sig string) (Wrapper, bool) {
+	wrappersMu.RLock()
+	defer wrappersMu.RUnlock()
+
+	w, ok := wrappers[sig]
+
+	return w, ok
+}
+
+// wrappersBySelector returns the registered wrappers with the selector, sorted by signature.
+func wrappersBySelector(selector []byte) []Wrapper {
+	wrappersMu.RLock()
+	defer wrappersMu.RUnlock()
+
+	var list []Wrapper
+	for sig, w := range wrappers {
+		if bytes.Equal(evmtools.MethodID(sig), selector) {
+			list = append(list, w)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Signature < list[j].Signature
+	})
+
+	return list
+}
+
+// expand decodes the calls nested in the arguments of the call at the depth.
+// Registered wrappers are unwrapped as described, and the bytes arguments of other calls
+// are kept if they decode as calldata.
+func (d *CalldataDecoder) expand(ctx context.Context, call *DecodedCall, depth int) {
+	if depth >= d.maxDepth {
+		return
+	}
+
+	w, ok := LookupWrapper(call.Signature.String())
+	switch {
+	case ok && w.Unwrap != nil:
+		d.unwrap(ctx, call, w.Unwrap, depth)
+
+	case ok:
+		call.Nested = w.nestedCalls(call.Args)
+		for i := range call.Nested {
+			// the errors of the resolver only mean the nested calls are unknown
+			call.Nested[i].Calls, _ = d.decode(ctx, call.Nested[i].Data, depth+1)
+		}
+
+	default:
+		var values []pathValue
+		for _, arg := range call.Args {
+			values = appendBytesValues(values, arg.Type, reflect.ValueOf(arg.Value), arg.Name)
+		}
+
+		for _, v := range values {
+			data := v.value.Bytes()
+			if len(data) < 4 || (len(data)-4)%32 != 0 {
+				continue
+			}
+
+			calls, _ := d.decode(ctx, data, depth+1)
+			if len(calls) > 0 {
+				call.Nested = append(call.Nested, NestedCall{Path: v.path, Data: data, Calls: calls})
+			}
+		}
+	}
+}
+
+// pseudoWrappers unwrap the pseudo-calls returned by the wrappers with custom encodings.
+// Unlike the registered wrappers, they are not candidate signatures of their selectors.
+var pseudoWrappers = map[string]func(args []DecodedArg) []NestedCall{
+	universalRouterSubPlan: unwrapUniversalRouter,
+}
+
+// unwrap decodes the calls nested in the arguments of the call with a custom encoding at the depth,
+// unwrapping the returned pseudo-calls listed in pseudoWrappers in turn.
+func (d *CalldataDecoder) unwrap(ctx context.Context, call *DecodedCall, unwrap func([]DecodedArg) []NestedCall, depth int) {
+	call.Nested = unwrap(call.Args)
+	for i := range call.Nested {
+		for j := range call.Nested[i].Calls {
+			nested := &call.Nested[i].Calls[j]
+
+			u, ok := pseudoWrappers[nested.Signature.String()]
+			switch {
+			case !ok:
+				d.expand(ctx, nested, depth+1)
+			case depth+1 < d.maxDepth:
+				d.unwrap(ctx, nested, u, depth+1)
+			}
+		}
+	}
+}
+
+// nestedCalls returns the calldata and the targets found in the decoded arguments of the wrapper.
+func (w *Wrapper) nestedCalls(args []DecodedArg) []NestedCall {
+	data := walkPath(w.fsig.inputs, args, w.Data)
+
+	var to []pathValue
+	if w.To != "" {
+		to = walkPath(w.fsig.inputs, args, w.To)
+	}
+
+	nested := make([]NestedCall, 0, len(data))
+	for i, v := range data {
+		call := NestedCall{Path: v.path, Data: v.value.Bytes()}
+
+		var target reflect.Value
+		switch {
+		case len(to) == 1:
+			target = to[0].value
+		case len(to) == len(data):
+			target = to[i].value
+		}
+
+		if target.IsValid() {
+			if addr, ok := target.Interface().(common.Address); ok {
+				call.To = &addr
+			}
+		}
+
+		nested = append(nested, call)
+	}
+
+	return nested
+}
+
+// pathValue is a decoded value found by its path.
+type pathValue struct {
+	// path is the concrete path of the value, e.g. "calls[1].callData"
+	path string
+
+	typ   ArgType
+	value reflect.Value
+}
+
+// walkPath returns the values of the decoded arguments of the parameters at the path.
+// The path must be checked with checkPathType.
+func walkPath(params []FuncParam, args []DecodedArg, path string) []pathValue {
+	var values []pathValue
+
+	for i, segment := range strings.Split(path, ".") {
+		name := strings.TrimSuffix(segment, "[]")
+
+		var next []pathValue
+		if i == 0 {
+			idx := paramIndex(params, name)
+			if idx < 0 || idx >= len(args) {
+				return nil
+			}
+			next = []pathValue{{path: name, typ: params[idx].Type, value: reflect.ValueOf(args[idx].Value)}}
+		} else {
+			for _, v := range values {
+				idx := paramIndex(v.typ.Components, name)
+				if idx < 0 || v.value.Kind() != reflect.Struct {
+					return nil
+				}
+				next = append(next, pathValue{
+					path:  v.path + "." + name,
+					typ:   v.typ.Components[idx].Type,
+					value: v.value.Field(idx),
+				})
+			}
+		}
+
+		if strings.HasSuffix(segment, "[]") {
+			var elems []pathValue
+			for _, v := range next {
+				for k := 0; k < v.value.Len(); k++ {
+					elems = append(elems, pathValue{
+						path:  v.path + "[" + strconv.Itoa(k) + "]",
+						typ:   *v.typ.Elem,
+						value: v.value.Index(k),
+					})
+				}
+			}
+			next = elems
+		}
+
+		values = next
+	}
+
+	return values
+}
+
+// checkPathType returns an error if the path doesn't lead to the values of the kind.
+func checkPathType(params []FuncParam, path string, kind ArgKind) error {
+	typ := TupleType(params...)
+	for _, segment := range strings.Split(path, ".") {
+		name := strings.TrimSuffix(segment, "[]")
+
+		idx := -1
+		if typ.Kind == ArgKindTuple {
+			idx = paramIndex(typ.Components, name)
+		}
+		if idx < 0 {
+			return fmt.Errorf("evmfuncs: no %q in path %q", name, path)
+		}
+		typ = typ.Components[idx].Type
+
+		if strings.HasSuffix(segment, "[]") {
+			if typ.Kind != ArgKindArray && typ.Kind != ArgKindSlice {
+				return fmt.Errorf("evmfuncs: %q is not an array in path %q", name, path)
+			}
+			typ = *typ.Elem
+		}
+	}
+
+	if typ.Kind != kind {
+		return fmt.Errorf("evmfuncs: path %q is %s, expected %s", path, typ, kind)
+	}
+
+	return nil
+}
+
+// appendBytesValues appends the bytes values found in the value of the type.
+func appendBytesValues(values []pathValue, typ ArgType, v reflect.Value, path string) []pathValue {
+	switch typ.Kind {
+	case ArgKindBytes:
+		return append(values, pathValue{path: path, typ: typ, value: v})
+	case ArgKindArray, ArgKindSlice:
+		for k := 0; k < v.Len(); k++ {
+			values = appendBytesValues(values, *typ.Elem, v.Index(k), path+"["+strconv.Itoa(k)+"]")
+		}
+	case ArgKindTuple:
+		for k, c := range typ.Components {
+			name := c.Name
+			if name == "" {
+				name = fmt.Sprintf("field%d", k)
+			}
+			values = appendBytesValues(values, c.Type, v.Field(k), path+"."+name)
+		}
+	}
+
+	return values
+}
+
+func paramIndex(params []FuncParam, name string) int {
+	for i, p := range params {
+		if p.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+func mustRegisterWrapper(w Wrapper) {
+	if err := RegisterWrapper(w); err != nil {
+		panic(err)
+	}
+}
+
+func init() {
+	for _, w := range []Wrapper{
+		{Signature: "multicall(bytes[] data)", Data: "data[]"},
+		{Signature: "multicall(uint256 deadline, bytes[] data)", Data: "data[]"},
+		{Signature: "multicall(bytes32 previousBlockhash, bytes[] data)", Data: "data[]"},
+		{
+			Signature: "aggregate((address target, bytes callData)[] calls)",
+			Data:      "calls[].callData",
+			To:        "calls[].target",
+		},
+		{
+			Signature: "tryAggregate(bool requireSuccess, (address target, bytes callData)[] calls)",
+			Data:      "calls[].callData",
+			To:        "calls[].target",
+		},
+		{
+			Signature: "blockAndAggregate((address target, bytes callData)[] calls)",
+			Data:      "calls[].callData",
+			To:        "calls[].target",
+		},
+		{
+			Signature: "aggregate3((address target, bool allowFailure, bytes callData)[] calls)",
+			Data:      "calls[].callData",
+			To:        "calls[].target",
+		},
+		{
+			Signature: "aggregate3Value((address target, bool allowFailure, uint256 value, bytes callData)[] calls)",
+			Data:      "calls[].callData",
+			To:        "calls[].target",
+		},
+		{
+			// Gnosis Safe
+			Signature: "execTransaction(address to, uint256 value, bytes data, uint8 operation, uint256 safeTxGas, " +
+				"uint256 baseGas, uint256 gasPrice, address gasToken, address refundReceiver, bytes signatures)",
+			Data: "data",
+			To:   "to",
+		},
+		{
+			// ERC-4337 accounts
+			Signature: "execute(address dest, uint256 value, bytes func)",
+			Data:      "func",
+			To:        "dest",
+		},
+		{
+			Signature: "executeBatch(address[] dest, bytes[] func)",
+			Data:      "func[]",
+			To:        "dest[]",
+		},
+		{
+			Signature: "execute(bytes commands, bytes[] inputs)",
+			Unwrap:    unwrapUniversalRouter,
+		},
+		{
+			Signature: "execute(bytes commands, bytes[] inputs, uint256 deadline)",
+			Unwrap:    unwrapUniversalRouter,
+		},
+	} {
+		mustRegisterWrapper(w)
+	}
+}
@@ -0,0 +1,240 @@
+package evmfuncs
+
+import (
+	"context"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/kirillDanshin/evmtools"
+)
+
+const (
+	testToken  = "0x5a5b644fb1a3ca046317fe82bc695fff7bacf30c"
+	testTarget = "0x1f9840a85d5af5bf1d1762f925bdaddc4201f984"
+)
+
+func mustPack(t *testing.T, sig string, args ...string) []byte {
+	t.Helper()
+
+	fsig, err := NewFuncSignatureFromString(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	values, err := ParseArgValues(fsig.Inputs(), args)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := fsig.PackInput(values...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return data
+}
+
+// formatCallTree formats the best ranked calls of the tree, one per line, indented by depth.
+func formatCallTree(calls []DecodedCall, indent string) string {
+	if len(calls) == 0 {
+		return ""
+	}
+
+	out := indent + calls[0].Signature.String() + "\n"
+	for _, n := range calls[0].Nested {
+		out += indent + "  " + n.Path
+		if n.To != nil {
+			out += " to " + strings.ToLower(n.To.Hex())
+		}
+		out += "\n" + formatCallTree(n.Calls, indent+"    ")
+	}
+
+	return out
+}
+
+func TestCalldataDecoder_Nested(t *testing.T) {
+	transfer := hex.EncodeToString(mustPack(t, "transfer(address,uint256)", testToken, "1e18"))
+	approve := hex.EncodeToString(mustPack(t, "approve(address,uint256)", testToken, "0"))
+	multicall := hex.EncodeToString(mustPack(t, "multicall(bytes[])", "[0x"+transfer+", 0x"+approve+"]"))
+
+	tests := []struct {
+		name     string
+		data     []byte
+		resolver SelectorResolver
+		maxDepth int
+		want     string
+	}{
+		{
+			name: "multicall",
+			data: mustPack(t, "multicall(uint256,bytes[])", "1700000000", "[0x"+transfer+", 0x"+approve+"]"),
+			want: "multicall(uint256,bytes[])\n" +
+				"  data[0]\n" +
+				"    transfer(address,uint256)\n" +
+				"  data[1]\n" +
+				"    approve(address,uint256)\n",
+		},
+		{
+			name: "aggregate3",
+			data: mustPack(t, "aggregate3((address,bool,bytes)[])",
+				"[("+testTarget+", true, 0x"+transfer+"), ("+testToken+", false, 0x)]"),
+			want: "aggregate3((address,bool,bytes)[])\n" +
+				"  calls[0].callData to " + testTarget + "\n" +
+				"    transfer(address,uint256)\n" +
+				"  calls[1].callData to " + testToken + "\n",
+		},
+		{
+			name: "safe_multicall",
+			data: mustPack(t, "execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)",
+				testTarget, "0", "0x"+multicall, "1", "0", "0", "0",
+				"0x0000000000000000000000000000000000000000", "0x0000000000000000000000000000000000000000", "0x"),
+			want: "execTransaction(address,uint256,bytes,uint8,uint256,uint256,uint256,address,address,bytes)\n" +
+				"  data to " + testTarget + "\n" +
+				"    multicall(bytes[])\n" +
+				"      data[0]\n" +
+				"        transfer(address,uint256)\n" +
+				"      data[1]\n" +
+				"        approve(address,uint256)\n",
+		},
+		{
+			name:     "depth_limit",
+			data:     mustPack(t, "execute(address,uint256,bytes)", testTarget, "0", "0x"+multicall),
+			maxDepth: 1,
+			want: "execute(address,uint256,bytes)\n" +
+				"  func to " + testTarget + "\n" +
+				"    multicall(bytes[])\n",
+		},
+		{
+			name: "execute_batch",
+			data: mustPack(t, "executeBatch(address[],bytes[])",
+				"["+testToken+", "+testTarget+"]", "[0x"+transfer+", 0x"+approve+"]"),
+			want: "executeBatch(address[],bytes[])\n" +
+				"  func[0] to " + testToken + "\n" +
+				"    transfer(address,uint256)\n" +
+				"  func[1] to " + testTarget + "\n" +
+				"    approve(address,uint256)\n",
+		},
+		{
+			name: "generic_bytes",
+			data: mustPack(t, "relay(address,bytes)", testTarget, "0x"+transfer),
+			resolver: staticResolver{
+				hex.EncodeToString(mustPack(t, "relay(address,bytes)", testTarget, "0x")[:4]): {"relay(address,bytes)"},
+			},
+			want: "relay(address,bytes)\n" +
+				"  arg1\n" +
+				"    transfer(address,uint256)\n",
+		},
+		{
+			name: "generic_bytes_not_calldata",
+			data: mustPack(t, "relay(address,bytes)", testTarget, "0x"+transfer[:len(transfer)-2]+"01ff"),
+			resolver: staticResolver{
+				hex.EncodeToString(mustPack(t, "relay(address,bytes)", testTarget, "0x")[:4]): {"relay(address,bytes)"},
+			},
+			want: "relay(address,bytes)\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := []DecoderOption{WithSelectorResolver(tt.resolver)}
+			if tt.maxDepth > 0 {
+				opts = append(opts, WithMaxCallDepth(tt.maxDepth))
+			}
+
+			calls, err := NewCalldataDecoder(opts...).Decode(context.Background(), tt.data)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if got := formatCallTree(calls, ""); got != tt.want {
+				t.Errorf("CalldataDecoder.Decode() =\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalldataDecoder_UniversalRouter(t *testing.T) {
+	wrap := mustPack(t, "WRAP_ETH(address,uint256)", testTarget, "1e18")[4:]
+	swap := mustPack(t, "V3_SWAP_EXACT_IN(address,uint256,uint256,bytes,bool)",
+		testToken, "1e18", "0", "0x"+strings.Repeat("ab", 43), "false")[4:]
+	subPlan := mustPack(t, "EXECUTE_SUB_PLAN(bytes,bytes[])", "0x0b", "[0x"+hex.EncodeToString(wrap)+"]")[4:]
+
+	data := mustPack(t, "execute(bytes,bytes[],uint256)",
+		"0x0b80217f",
+		"[0x"+hex.EncodeToString(wrap)+", 0x"+hex.EncodeToString(swap)+", 0x"+hex.EncodeToString(subPlan)+", 0x01]",
+		"1700000000")
+
+	calls, err := DecodeCalldata(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := "execute(bytes,bytes[],uint256)\n" +
+		"  inputs[0]\n" +
+		"    WRAP_ETH(address,uint256)\n" +
+		"  inputs[1]\n" +
+		"    V3_SWAP_EXACT_IN(address,uint256,uint256,bytes,bool)\n" +
+		"  inputs[2]\n" +
+		"    EXECUTE_SUB_PLAN(bytes,bytes[])\n" +
+		"      inputs[0]\n" +
+		"        WRAP_ETH(address,uint256)\n" +
+		"  inputs[3]\n"
+	if got := formatCallTree(calls, ""); got != want {
+		t.Errorf("DecodeCalldata() =\n%s\nwant\n%s", got, want)
+	}
+
+	args := calls[0].Nested[1].Calls[0].Args
+	if args[0].Name != "recipient" || args[0].Value != common.HexToAddress(testToken) {
+		t.Errorf("DecodeCalldata() V3_SWAP_EXACT_IN args = %+v", args)
+	}
+
+	// the commands are pseudo-functions, not candidates of their selectors
+	calls, err = DecodeCalldata(mustPack(t, "EXECUTE_SUB_PLAN(bytes,bytes[])", "0x0b", "[0x"+hex.EncodeToString(wrap)+"]"))
+	if err != nil || len(calls) != 0 {
+		t.Errorf("DecodeCalldata() of EXECUTE_SUB_PLAN = %v, %v, want no calls", calls, err)
+	}
+}
+
+func TestRegisterWrapper(t *testing.T) {
+	tests := []struct {
+		name    string
+		wrapper Wrapper
+		wantErr bool
+	}{
+		{
+			name:    "valid",
+			wrapper: Wrapper{Signature: "forward(address target, (uint256 gas, bytes data) call)", Data: "call.data", To: "target"},
+		},
+		{name: "invalid_signature", wrapper: Wrapper{Signature: "forward(address target", Data: "data"}, wantErr: true},
+		{name: "unknown_param", wrapper: Wrapper{Signature: "forward(bytes data)", Data: "payload"}, wantErr: true},
+		{name: "not_bytes", wrapper: Wrapper{Signature: "forward(bytes[] data)", Data: "data"}, wantErr: true},
+		{name: "not_array", wrapper: Wrapper{Signature: "forward(bytes data)", Data: "data[]"}, wantErr: true},
+		{name: "to_not_address", wrapper: Wrapper{Signature: "forward(uint256 to, bytes data)", Data: "data", To: "to"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := RegisterWrapper(tt.wrapper)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RegisterWrapper() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			sig := "forward(address,(uint256,bytes))"
+			if _, ok := LookupWrapper(sig); !ok {
+				t.Error("LookupWrapper() didn't find the registered wrapper")
+			}
+
+			// the wrapper bonus is local to CalldataDecoder
+			if score := ScoreSignature(evmtools.MethodID(sig), sig, -1); score != 0 {
+				t.Errorf("ScoreSignature() of the registered wrapper = %d, want 0", score)
+			}
+
+			calls, err := DecodeCalldata(mustPack(t, sig, testTarget, "(1, 0x)"))
+			if err != nil || len(calls) != 1 || calls[0].Score != scoreWrapper {
+				t.Errorf("DecodeCalldata() of the registered wrapper = %+v, %v, want score %d", calls, err, scoreWrapper)
+			}
+		})
+	}
+}
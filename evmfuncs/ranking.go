@@ -0,0 +1,58 @@
+package evmfuncs
+
+import (
+	"bytes"
+
+	"github.com/kirillDanshin/evmtools"
+)
+
+// ScoredSignature is a candidate text signature of a function selector.
+type ScoredSignature struct {
+	Signature string
+
+	// Score is the ranking score of the signature, higher is better.
+	Score int
+}
+
+// Signature ranking weights.
+const (
+	ScoreWellKnown        = 100
+	ScoreSpam             = -100
+	ScoreSnakeCase        = -20
+	ScoreSelectorMismatch = -1000
+
+	// MaxAgeScore is the maximum bonus of the older candidates, see ScoreSignature.
+	MaxAgeScore = 10
+)
+
+// ScoreSignature returns the ranking score of the candidate text signature of the selector.
+//
+// Signatures from the well-known dictionary are preferred, known spam patterns
+// and signatures not matching the selector are demoted. The age is the position of the candidate in
+// the resolver results ordered newest first, as 4byte.directory does: the older entries get a bonus
+// of one point per position, up to MaxAgeScore. A negative age means that the order is unknown.
+func ScoreSignature(selector []byte, sig string, age int) int {
+	score := 0
+	if age > 0 {
+		score = age
+		if score > MaxAgeScore {
+			score = MaxAgeScore
+		}
+	}
+
+	if !bytes.Equal(evmtools.MethodID(sig), selector) {
+		score += ScoreSelectorMismatch
+	}
+
+	if desc, ok := GetWellKnownFuncByMethodID(selector); ok && desc.Signature() == sig {
+		score += ScoreWellKnown
+	}
+
+	if IsLikelySpamSignature(sig) {
+		score += ScoreSpam
+	} else if IsSnakeCaseSignature(sig) {
+		score += ScoreSnakeCase
+	}
+
+	return score
+}
@@ -0,0 +1,381 @@
+package evmfuncs
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Data locations of the parameters in the human-readable signatures.
+const (
+	LocationMemory   = "memory"
+	LocationCalldata = "calldata"
+	LocationStorage  = "storage"
+)
+
+// SyntaxError is a malformed function signature.
+type SyntaxError struct {
+	// Offset is the byte offset of the error in the signature
+	Offset int
+
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("evmfuncs: offset %d: %s", e.Offset, e.Msg)
+}
+
+// typeAliases are the elementary types with a different canonical name.
+var typeAliases = map[string]string{
+	"uint":   "uint256",
+	"int":    "int256",
+	"byte":   "bytes1",
+	"fixed":  "fixed128x18",
+	"ufixed": "ufixed128x18",
+}
+
+// maxArrayLength is the maximum length of the fixed-length arrays.
+const maxArrayLength = 1 << 32
+
+var (
+	bytesTypeRe = regexp.MustCompile(`^bytes([1-9]|[12][0-9]|3[0-2])$`)
+	intTypeRe   = regexp.MustCompile(`^(u?)int([1-9][0-9]*)$`)
+	fixedTypeRe = regexp.MustCompile(`^(u?)fixed([1-9][0-9]*)x([1-9][0-9]*)$`)
+)
+
+// elementaryArgType returns the elementary type of the canonical name.
+func elementaryArgType(name string) (ArgType, bool) {
+	switch name {
+	case "address":
+		return ArgTypeAddress, true
+	case "bool":
+		return ArgTypeBool, true
+	case "string":
+		return ArgTypeString, true
+	case "bytes":
+		return ArgTypeBytes, true
+	case "function":
+		return ArgTypeFunction, true
+	}
+
+	validBits := func(bits int) bool {
+		return bits > 0 && bits <= 256 && bits%8 == 0
+	}
+
+	if m := bytesTypeRe.FindStringSubmatch(name); m != nil {
+		size, _ := strconv.Atoi(m[1])
+		return FixedBytesType(size), true
+	}
+
+	if m := intTypeRe.FindStringSubmatch(name); m != nil {
+		bits, err := strconv.Atoi(m[2])
+		if err != nil || !validBits(bits) {
+			return ArgTypeUnknown, false
+		}
+
+		if m[1] == "u" {
+			return UintType(bits), true
+		}
+
+		return IntType(bits), true
+	}
+
+	if m := fixedTypeRe.FindStringSubmatch(name); m != nil {
+		bits, err := strconv.Atoi(m[2])
+		if err != nil || !validBits(bits) {
+			return ArgTypeUnknown, false
+		}
+
+		decimals, err := strconv.Atoi(m[3])
+		if err != nil || decimals <= 0 || decimals > 80 {
+			return ArgTypeUnknown, false
+		}
+
+		if m[1] == "u" {
+			return UfixedType(bits, decimals), true
+		}
+
+		return FixedType(bits, decimals), true
+	}
+
+	return ArgTypeUnknown, false
+}
+
+// parseSignature parses a function or event signature, either canonical, e.g. "swap((address,uint256)[],bytes)",
+// or human-readable, e.g. "function transfer(address to, uint256 amount) external returns (bool)".
+//
+// A signature without parentheses is a name of the function without inputs.
+func parseSignature(sig string) (string, []FuncParam, []FuncParam, error) {
+	p := &sigParser{s: sig}
+
+	p.skipSpace()
+	if p.keyword("function") || p.keyword("event") {
+		p.skipSpace()
+	}
+
+	name := p.ident()
+	if name == "" {
+		return "", nil, nil, p.errorf("expected function name")
+	}
+
+	p.skipSpace()
+	if p.eof() {
+		return name, nil, nil, nil
+	}
+
+	inputs, err := p.paramList()
+	if err != nil {
+		return "", nil, nil, err
+	}
+
+	var outputs []FuncParam
+	for {
+		p.skipSpace()
+		if p.eof() {
+			break
+		}
+
+		start := p.pos
+		word := p.ident()
+		switch word {
+		case "":
+			return "", nil, nil, p.errorf("unexpected %q", p.s[p.pos:])
+		case "returns":
+			p.skipSpace()
+			if outputs, err = p.paramList(); err != nil {
+				return "", nil, nil, err
+			}
+		case "anonymous", "external", "public", "internal", "private", "view", "pure", "payable",
+			"nonpayable", "virtual", "override", "constant":
+		default:
+			p.pos = start
+			return "", nil, nil, p.errorf("unexpected %q", word)
+		}
+	}
+
+	return name, inputs, outputs, nil
+}
+
+// ParseParams parses a comma-separated list of parameters in parentheses, e.g. "(address to, uint256 amount)".
+func ParseParams(params string) ([]FuncParam, error) {
+	p := &sigParser{s: params}
+
+	p.skipSpace()
+	list, err := p.paramList()
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+	if !p.eof() {
+		return nil, p.errorf("unexpected %q", p.s[p.pos:])
+	}
+
+	return list, nil
+}
+
+// sigParser is a recursive-descent parser of the signature syntax:
+//
+//	signature := ["function"] name [params {modifier} ["returns" params]]
+//	params    := "(" [param {"," param}] ")"
+//	param     := type {"payable" | "indexed" | location} [name]
+//	type      := (elementary | ["tuple"] params) {"[" [length] "]"}
+type sigParser struct {
+	s   string
+	pos int
+}
+
+func (p *sigParser) paramList() ([]FuncParam, error) {
+	if !p.consume('(') {
+		return nil, p.errorf("expected '('")
+	}
+
+	params := []FuncParam{}
+	p.skipSpace()
+	if p.consume(')') {
+		return params, nil
+	}
+
+	for {
+		param, err := p.param()
+		if err != nil {
+			return nil, err
+		}
+		params = append(params, param)
+
+		p.skipSpace()
+		switch {
+		case p.consume(','):
+			p.skipSpace()
+		case p.consume(')'):
+			return params, nil
+		default:
+			return nil, p.errorf("expected ',' or ')'")
+		}
+	}
+}
+
+func (p *sigParser) param() (FuncParam, error) {
+	var param FuncParam
+
+	p.skipSpace()
+	typ, err := p.argType()
+	if err != nil {
+		return param, err
+	}
+	param.Type = typ
+
+	for {
+		p.skipSpace()
+		start := p.pos
+		word := p.ident()
+
+		switch word {
+		case "payable":
+			if param.Type.Kind != ArgKindAddress {
+				p.pos = start
+				return param, p.errorf("payable %s", param.Type)
+			}
+		case "indexed":
+			param.Indexed = true
+		case LocationMemory, LocationCalldata, LocationStorage:
+			if param.Location != "" {
+				p.pos = start
+				return param, p.errorf("duplicate data location %q", word)
+			}
+			param.Location = word
+		default:
+			param.Name = word
+			return param, nil
+		}
+	}
+}
+
+func (p *sigParser) argType() (ArgType, error) {
+	var typ ArgType
+
+	start := p.pos
+	if p.keyword("tuple") {
+		p.skipSpace()
+		if p.peek() != '(' {
+			p.pos = start
+		}
+	}
+
+	if p.peek() == '(' {
+		components, err := p.paramList()
+		if err != nil {
+			return typ, err
+		}
+
+		typ = TupleType(components...)
+	} else {
+		name := p.ident()
+		if name == "" {
+			return typ, p.errorf("expected type")
+		}
+
+		if alias, ok := typeAliases[name]; ok {
+			name = alias
+		}
+
+		var ok bool
+		if typ, ok = elementaryArgType(name); !ok {
+			p.pos = start
+			return typ, p.errorf("unknown type %q", name)
+		}
+	}
+
+	for p.consume('[') {
+		start := p.pos
+		for p.pos < len(p.s) && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+			p.pos++
+		}
+		digits := p.s[start:p.pos]
+
+		if !p.consume(']') {
+			return typ, p.errorf("expected ']'")
+		}
+
+		if digits == "" {
+			typ = SliceType(typ)
+			continue
+		}
+
+		length, err := strconv.Atoi(digits)
+		if err != nil || digits[0] == '0' || uint64(length) > maxArrayLength {
+			p.pos = start
+			return typ, p.errorf("invalid array length %q", digits)
+		}
+
+		typ = ArrayType(typ, length)
+	}
+
+	if typ.HeadSize() >= maxHeadSize {
+		p.pos = start
+		return typ, p.errorf("type %s is too large", typ)
+	}
+
+	return typ, nil
+}
+
+func (p *sigParser) ident() string {
+	start := p.pos
+	for p.pos < len(p.s) && isIdentChar(p.s[p.pos]) {
+		p.pos++
+	}
+
+	return p.s[start:p.pos]
+}
+
+// keyword consumes the keyword if it is followed by a non-identifier character.
+func (p *sigParser) keyword(kw string) bool {
+	if !strings.HasPrefix(p.s[p.pos:], kw) {
+		return false
+	}
+
+	end := p.pos + len(kw)
+	if end < len(p.s) && isIdentChar(p.s[end]) {
+		return false
+	}
+
+	p.pos = end
+
+	return true
+}
+
+func (p *sigParser) skipSpace() {
+	for p.pos < len(p.s) && (p.s[p.pos] == ' ' || p.s[p.pos] == '\t' || p.s[p.pos] == '\n' || p.s[p.pos] == '\r') {
+		p.pos++
+	}
+}
+
+func (p *sigParser) consume(c byte) bool {
+	if p.peek() != c {
+		return false
+	}
+	p.pos++
+
+	return true
+}
+
+func (p *sigParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+
+	return p.s[p.pos]
+}
+
+func (p *sigParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *sigParser) errorf(format string, args ...interface{}) error {
+	return &SyntaxError{Offset: p.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func isIdentChar(c byte) bool {
+	return c == '_' || c == '$' || c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
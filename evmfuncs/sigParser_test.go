@@ -0,0 +1,201 @@
+package evmfuncs
+
+import (
+	"encoding/hex"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestParseSignature(t *testing.T) {
+	tests := []struct {
+		name        string
+		sig         string
+		wantName    string
+		wantInputs  []FuncParam
+		wantOutputs []FuncParam
+		wantErr     bool
+	}{
+		{
+			name:       "canonical",
+			sig:        "transfer(address,uint256)",
+			wantName:   "transfer",
+			wantInputs: []FuncParam{{Type: ArgTypeAddress}, {Type: ArgTypeUint}},
+		},
+		{
+			name:       "no_inputs",
+			sig:        "totalSupply()",
+			wantName:   "totalSupply",
+			wantInputs: []FuncParam{},
+		},
+		{
+			name:     "name_only",
+			sig:      "totalSupply",
+			wantName: "totalSupply",
+		},
+		{
+			name:     "tuple_array",
+			sig:      "swap((address,uint256)[],bytes)",
+			wantName: "swap",
+			wantInputs: []FuncParam{
+				{Type: SliceType(TupleType(FuncParam{Type: ArgTypeAddress}, FuncParam{Type: ArgTypeUint}))},
+				{Type: ArgTypeBytes},
+			},
+		},
+		{
+			name:     "nested_arrays_and_tuples",
+			sig:      "f(uint256[2][],(bool,(string,bytes32[3]))[4])",
+			wantName: "f",
+			wantInputs: []FuncParam{
+				{Type: SliceType(ArrayType(ArgTypeUint, 2))},
+				{Type: ArrayType(TupleType(
+					FuncParam{Type: ArgTypeBool},
+					FuncParam{Type: TupleType(
+						FuncParam{Type: ArgTypeString},
+						FuncParam{Type: ArrayType(FixedBytesType(32), 3)},
+					)},
+				), 4)},
+			},
+		},
+		{
+			name:     "human_readable",
+			sig:      "function exactInput(tuple(bytes path, address recipient, uint amountIn) calldata params) external payable returns (uint256 amountOut)",
+			wantName: "exactInput",
+			wantInputs: []FuncParam{
+				{
+					Name: "params",
+					Type: TupleType(
+						FuncParam{Name: "path", Type: ArgTypeBytes},
+						FuncParam{Name: "recipient", Type: ArgTypeAddress},
+						FuncParam{Name: "amountIn", Type: ArgTypeUint},
+					),
+					Location: LocationCalldata,
+				},
+			},
+			wantOutputs: []FuncParam{{Name: "amountOut", Type: ArgTypeUint}},
+		},
+		{
+			name:     "event",
+			sig:      "event Transfer(address indexed from, address indexed to, uint value)",
+			wantName: "Transfer",
+			wantInputs: []FuncParam{
+				{Name: "from", Type: ArgTypeAddress, Indexed: true},
+				{Name: "to", Type: ArgTypeAddress, Indexed: true},
+				{Name: "value", Type: ArgTypeUint},
+			},
+		},
+		{
+			name:     "aliases_and_locations",
+			sig:      "f(byte b, address payable to, string memory s, int[] storage xs)",
+			wantName: "f",
+			wantInputs: []FuncParam{
+				{Name: "b", Type: FixedBytesType(1)},
+				{Name: "to", Type: ArgTypeAddress},
+				{Name: "s", Type: ArgTypeString, Location: LocationMemory},
+				{Name: "xs", Type: SliceType(ArgTypeInt), Location: LocationStorage},
+			},
+		},
+		{name: "unbalanced", sig: "f((address,uint256)", wantErr: true},
+		{name: "empty_param", sig: "f(uint256,)", wantErr: true},
+		{name: "unknown_type", sig: "f(uint7)", wantErr: true},
+		{name: "bytes33", sig: "f(bytes33)", wantErr: true},
+		{name: "leading_zero_bits", sig: "f(uint08)", wantErr: true},
+		{name: "leading_zero_decimals", sig: "f(fixed128x018)", wantErr: true},
+		{name: "leading_zero_length", sig: "f(uint256[02])", wantErr: true},
+		{name: "empty_name", sig: "(uint256)", wantErr: true},
+		{name: "empty", sig: "", wantErr: true},
+		{name: "bad_array", sig: "f(uint256[2)", wantErr: true},
+		{name: "max_array_length", sig: "f(string[4294967296])", wantName: "f", wantInputs: []FuncParam{{Type: ArrayType(ArgTypeString, 1<<32)}}},
+		{name: "huge_array", sig: "f(uint256[9223372036854775807])", wantErr: true},
+		{name: "array_too_long", sig: "f(string[4294967297])", wantErr: true},
+		{name: "array_too_large", sig: "f(uint256[4294967296])", wantErr: true},
+		{name: "nested_array_too_large", sig: "f(uint256[1000000000000][1000000000000])", wantErr: true},
+		{name: "tuple_too_large", sig: "f((uint256[33554432],uint256[33554432]))", wantErr: true},
+		{name: "payable_uint", sig: "f(uint256 payable x)", wantErr: true},
+		{name: "two_locations", sig: "f(bytes memory calldata x)", wantErr: true},
+		{name: "trailing", sig: "f(uint256) x(", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name, inputs, outputs, err := parseSignature(tt.sig)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if name != tt.wantName {
+				t.Errorf("parseSignature() name = %q, want %q", name, tt.wantName)
+			}
+			if !reflect.DeepEqual(inputs, tt.wantInputs) {
+				t.Errorf("parseSignature() inputs = %+v, want %+v", inputs, tt.wantInputs)
+			}
+			if !reflect.DeepEqual(outputs, tt.wantOutputs) {
+				t.Errorf("parseSignature() outputs = %+v, want %+v", outputs, tt.wantOutputs)
+			}
+		})
+	}
+}
+
+func TestParseParams(t *testing.T) {
+	got, err := ParseParams("(uint256 amount, address)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []FuncParam{{Name: "amount", Type: ArgTypeUint}, {Type: ArgTypeAddress}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ParseParams() = %+v, want %+v", got, want)
+	}
+
+	if _, err := ParseParams("f(uint256)"); err == nil {
+		t.Error("ParseParams() with a function name succeeded")
+	}
+}
+
+func TestFuncSig_UnpackTuple(t *testing.T) {
+	fsig, err := NewFuncSignatureFromString("swap((address token, uint256 amount)[] legs, bytes data)")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got, want := fsig.String(), "swap((address,uint256)[],bytes)"; got != want {
+		t.Errorf("FuncSig.String() = %q, want %q", got, want)
+	}
+
+	data, err := hex.DecodeString("" +
+		"0000000000000000000000000000000000000000000000000000000000000040" +
+		"00000000000000000000000000000000000000000000000000000000000000a0" +
+		"0000000000000000000000000000000000000000000000000000000000000001" +
+		"0000000000000000000000005a5b644fb1a3ca046317fe82bc695fff7bacf30c" +
+		"000000000000000000000000000000000000000000000000000000000000002a" +
+		"0000000000000000000000000000000000000000000000000000000000000002" +
+		"abcd000000000000000000000000000000000000000000000000000000000000")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := fsig.UnpackInput(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	legs := reflect.ValueOf(got[0])
+	if legs.Len() != 1 {
+		t.Fatalf("FuncSig.UnpackInput() legs = %v, want 1 leg", got[0])
+	}
+
+	leg := legs.Index(0)
+	if token := leg.FieldByName("Token").Interface(); token != common.HexToAddress("0x5A5b644FB1A3ca046317fE82BC695FfF7bACF30C") {
+		t.Errorf("FuncSig.UnpackInput() token = %v", token)
+	}
+	if amount := leg.FieldByName("Amount").Interface().(*big.Int); amount.Int64() != 42 {
+		t.Errorf("FuncSig.UnpackInput() amount = %v, want 42", amount)
+	}
+	if !reflect.DeepEqual(got[1], []byte{0xab, 0xcd}) {
+		t.Errorf("FuncSig.UnpackInput() data = %x, want abcd", got[1])
+	}
+}
@@ -0,0 +1,37 @@
+package evmfuncs
+
+import (
+	"regexp"
+	"strings"
+)
+
+var spamSignaturePatterns = []*regexp.Regexp{
+	// advertisement of telegram channels, e.g. watch_tg_invmru_2f69f1b(address,address)
+	regexp.MustCompile(`_tg_[a-z0-9]+_`),
+	// generated selector collisions, e.g. func_2093253501(bytes) or _func_5437782296(address,address)
+	regexp.MustCompile(`^_?func_[0-9]+\(`),
+	// names with long numeric suffixes, e.g. niceFunctionHerePlzClick943230089(address,bool)
+	regexp.MustCompile(`[0-9]{6,}\(`),
+}
+
+// IsLikelySpamSignature returns true if the text signature looks like a spam or
+// a generated collision entry of public signature databases, such as 4byte.directory.
+func IsLikelySpamSignature(sig string) bool {
+	for _, re := range spamSignaturePatterns {
+		if re.MatchString(sig) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsSnakeCaseSignature returns true if the function name is a lowercase snake_case name,
+// which is unusual for Solidity code, but typical for brute-forced selector collisions
+// (e.g. many_msg_babbage(bytes1)). Constant-like names such as MAX_UINT() are not snake case.
+func IsSnakeCaseSignature(sig string) bool {
+	name, _ := parseName(sig)
+	name = strings.TrimLeft(name, "_")
+
+	return strings.Contains(name, "_") && strings.ToLower(name) == name
+}
@@ -0,0 +1,84 @@
+package evmfuncs
+
+import "fmt"
+
+const (
+	// universalRouterCommandMask selects the command type of the Universal Router command byte,
+	// the highest bit of which is the allow-revert flag
+	universalRouterCommandMask = 0x3f
+
+	// universalRouterSubPlan is the canonical signature of the EXECUTE_SUB_PLAN command
+	universalRouterSubPlan = "EXECUTE_SUB_PLAN(bytes,bytes[])"
+)
+
+// universalRouterCommands are the inputs of the Universal Router commands, described as pseudo-functions
+// named after the commands.
+var universalRouterCommands = func() map[byte]*FuncSig {
+	commands := map[byte]string{
+		0x00: "V3_SWAP_EXACT_IN(address recipient, uint256 amountIn, uint256 amountOutMin, bytes path, bool payerIsUser)",
+		0x01: "V3_SWAP_EXACT_OUT(address recipient, uint256 amountOut, uint256 amountInMax, bytes path, bool payerIsUser)",
+		0x02: "PERMIT2_TRANSFER_FROM(address token, address recipient, uint160 amount)",
+		0x03: "PERMIT2_PERMIT_BATCH(((address token, uint160 amount, uint48 expiration, uint48 nonce)[] details, " +
+			"address spender, uint256 sigDeadline) permitBatch, bytes signature)",
+		0x04: "SWEEP(address token, address recipient, uint256 amountMin)",
+		0x05: "TRANSFER(address token, address recipient, uint256 value)",
+		0x06: "PAY_PORTION(address token, address recipient, uint256 bips)",
+		0x08: "V2_SWAP_EXACT_IN(address recipient, uint256 amountIn, uint256 amountOutMin, address[] path, bool payerIsUser)",
+		0x09: "V2_SWAP_EXACT_OUT(address recipient, uint256 amountOut, uint256 amountInMax, address[] path, bool payerIsUser)",
+		0x0a: "PERMIT2_PERMIT(((address token, uint160 amount, uint48 expiration, uint48 nonce) details, " +
+			"address spender, uint256 sigDeadline) permitSingle, bytes signature)",
+		0x0b: "WRAP_ETH(address recipient, uint256 amountMin)",
+		0x0c: "UNWRAP_WETH(address recipient, uint256 amountMin)",
+		0x0d: "PERMIT2_TRANSFER_FROM_BATCH((address from, address to, uint160 amount, address token)[] batchDetails)",
+		0x0e: "BALANCE_CHECK_ERC20(address owner, address token, uint256 minBalance)",
+		0x21: "EXECUTE_SUB_PLAN(bytes commands, bytes[] inputs)",
+	}
+
+	parsed := make(map[byte]*FuncSig, len(commands))
+	for cmd, sig := range commands {
+		fsig, err := parseFuncSig(sig)
+		if err != nil {
+			panic(fmt.Sprintf("evmfuncs: universal router command %#x: %v", cmd, err))
+		}
+		parsed[cmd] = fsig
+	}
+
+	return parsed
+}()
+
+// unwrapUniversalRouter decodes the inputs of the Universal Router commands, the first two arguments of
+// execute(bytes commands, bytes[] inputs) and EXECUTE_SUB_PLAN. The commands are returned as pseudo-calls
+// named after them; the inputs of unknown commands are returned undecoded.
+func unwrapUniversalRouter(args []DecodedArg) []NestedCall {
+	if len(args) < 2 {
+		return nil
+	}
+
+	commands, ok := args[0].Value.([]byte)
+	if !ok {
+		return nil
+	}
+
+	inputs, ok := args[1].Value.([][]byte)
+	if !ok {
+		return nil
+	}
+
+	nested := make([]NestedCall, 0, len(commands))
+	for i, cmd := range commands {
+		if i >= len(inputs) {
+			break
+		}
+
+		call := NestedCall{Path: fmt.Sprintf("%s[%d]", args[1].Name, i), Data: inputs[i]}
+		if fsig, ok := universalRouterCommands[cmd&universalRouterCommandMask]; ok {
+			if decoded, err := decodeArgs(fsig.inputs, inputs[i]); err == nil {
+				call.Calls = []DecodedCall{{Signature: fsig, Args: decoded}}
+			}
+		}
+
+		nested = append(nested, call)
+	}
+
+	return nested
+}
@@ -0,0 +1,206 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmops
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+// Fork is an Ethereum hard fork that changed the instruction set or its semantics.
+// Forks are ordered, so they can be compared with < and >.
+type Fork uint8
+
+const (
+	Frontier Fork = iota
+	Homestead
+	TangerineWhistle
+	SpuriousDragon
+	Byzantium
+	Constantinople
+	Petersburg
+	Istanbul
+	Berlin
+	London
+	Merge
+	Shanghai
+	Cancun
+	Prague
+
+	// LatestFork is the latest supported fork, its instruction set is InstructionSet.
+	LatestFork = Prague
+)
+
+var forkNames = [...]string{
+	Frontier:         "Frontier",
+	Homestead:        "Homestead",
+	TangerineWhistle: "TangerineWhistle",
+	SpuriousDragon:   "SpuriousDragon",
+	Byzantium:        "Byzantium",
+	Constantinople:   "Constantinople",
+	Petersburg:       "Petersburg",
+	Istanbul:         "Istanbul",
+	Berlin:           "Berlin",
+	London:           "London",
+	Merge:            "Merge",
+	Shanghai:         "Shanghai",
+	Cancun:           "Cancun",
+	Prague:           "Prague",
+}
+
+// forkAliases are the alternative names of the forks, lowercase.
+var forkAliases = map[string]Fork{
+	"eip150":      TangerineWhistle,
+	"eip158":      SpuriousDragon,
+	"muirglacier": Istanbul,
+	"paris":       Merge,
+	"shapella":    Shanghai,
+	"dencun":      Cancun,
+	"pectra":      Prague,
+}
+
+func (f Fork) String() string {
+	if int(f) < len(forkNames) {
+		return forkNames[f]
+	}
+
+	return "Unknown"
+}
+
+// ForkByName returns the fork with the given name, case-insensitive,
+// e.g. "shanghai", "Paris" or "EIP150".
+func ForkByName(name string) (Fork, bool) {
+	name = strings.ToLower(strings.NewReplacer(" ", "", "_", "", "-", "").Replace(name))
+
+	for f, n := range forkNames {
+		if strings.ToLower(n) == name {
+			return Fork(f), true
+		}
+	}
+
+	f, ok := forkAliases[name]
+
+	return f, ok
+}
+
+// ForkByChainConfig returns the latest fork active at the given block of the chain.
+// The merge is not determined by the block number, so isMerge should be provided by the caller,
+// the same way as for params.ChainConfig.Rules.
+// Forks that are not known to the chain config, e.g. Prague, are never returned.
+func ForkByChainConfig(cfg *params.ChainConfig, number *big.Int, isMerge bool) Fork {
+	switch {
+	case cfg.IsCancun(number):
+		return Cancun
+	case cfg.IsShanghai(number):
+		return Shanghai
+	case isMerge:
+		return Merge
+	case cfg.IsLondon(number):
+		return London
+	case cfg.IsBerlin(number):
+		return Berlin
+	case cfg.IsIstanbul(number):
+		return Istanbul
+	case cfg.IsPetersburg(number):
+		return Petersburg
+	case cfg.IsConstantinople(number):
+		return Constantinople
+	case cfg.IsByzantium(number):
+		return Byzantium
+	case cfg.IsEIP158(number):
+		return SpuriousDragon
+	case cfg.IsEIP150(number):
+		return TangerineWhistle
+	case cfg.IsHomestead(number):
+		return Homestead
+	}
+
+	return Frontier
+}
+
+// introducedIn lists the instructions that were not defined in Frontier, by the fork introducing them.
+var introducedIn = map[Opcode]Fork{
+	0xf4: Homestead, // DELEGATECALL
+
+	0x3d: Byzantium, // RETURNDATASIZE
+	0x3e: Byzantium, // RETURNDATACOPY
+	0xfa: Byzantium, // STATICCALL
+	0xfd: Byzantium, // REVERT
+
+	0x1b: Constantinople, // SHL
+	0x1c: Constantinople, // SHR
+	0x1d: Constantinople, // SAR
+	0x3f: Constantinople, // EXTCODEHASH
+	0xf5: Constantinople, // CREATE2
+
+	0x46: Istanbul, // CHAINID
+	0x47: Istanbul, // SELFBALANCE
+
+	0x48: London, // BASEFEE
+
+	0x5f: Shanghai, // PUSH0
+
+	0x49: Cancun, // BLOBHASH
+	0x4a: Cancun, // BLOBBASEFEE
+	0x5c: Cancun, // TLOAD
+	0x5d: Cancun, // TSTORE
+	0x5e: Cancun, // MCOPY
+}
+
+// IntroducedIn returns the fork that introduced the instruction.
+// Undefined instructions are reported as introduced in Frontier.
+func IntroducedIn(code Opcode) Fork {
+	return introducedIn[code]
+}
+
+// IsActive returns true if the instruction is defined in the fork.
+func (f Fork) IsActive(code Opcode) bool {
+	return !strings.HasPrefix(ForkInstructionSet(f)[code].Mnemonic, "__UNDEFINED_INSTRUCTION")
+}
+
+var forkInstructionSets [LatestFork + 1][0x100]Instruction
+
+// ForkInstructionSet returns the instruction set of the fork.
+// Instructions that are not active in the fork are undefined.
+// The returned table is shared and must not be modified.
+func ForkInstructionSet(f Fork) *[0x100]Instruction {
+	if f > LatestFork {
+		f = LatestFork
+	}
+
+	return &forkInstructionSets[f]
+}
+
+func initForkInstructionSets() {
+	for f := range forkInstructionSets {
+		set := InstructionSet
+		for code, introduced := range introducedIn {
+			if introduced > Fork(f) {
+				set[code] = undefinedInstruction(code)
+			}
+		}
+
+		applyGasHistory(&set, Fork(f))
+
+		if Fork(f) < Merge {
+			set[0x44].Mnemonic = "DIFFICULTY"
+			set[0x44].Description = "Get the block's difficulty"
+		}
+
+		forkInstructionSets[f] = set
+	}
+}
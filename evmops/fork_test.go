@@ -0,0 +1,72 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmops
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestForkByName(t *testing.T) {
+	tests := []struct {
+		name   string
+		want   Fork
+		wantOK bool
+	}{
+		{"frontier", Frontier, true},
+		{"Tangerine Whistle", TangerineWhistle, true},
+		{"EIP150", TangerineWhistle, true},
+		{"paris", Merge, true},
+		{"SHANGHAI", Shanghai, true},
+		{"cancun", Cancun, true},
+		{"prague", Prague, true},
+		{"osaka", 0, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := ForkByName(tt.name)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("ForkByName() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestForkByChainConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		number  int64
+		isMerge bool
+		want    Fork
+	}{
+		{"genesis", 0, false, Frontier},
+		{"homestead", 1_150_000, false, Homestead},
+		{"dao", 1_920_000, false, Homestead},
+		{"byzantium", 4_370_000, false, Byzantium},
+		{"petersburg", 7_280_000, false, Petersburg},
+		{"london", 12_965_000, false, London},
+		{"merge", 15_537_394, true, Merge},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ForkByChainConfig(params.MainnetChainConfig, big.NewInt(tt.number), tt.isMerge)
+			if got != tt.want {
+				t.Errorf("ForkByChainConfig() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
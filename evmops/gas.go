@@ -0,0 +1,151 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmops
+
+import "strings"
+
+// DynamicGas is a set of the gas cost components of an instruction that depend on the execution state.
+type DynamicGas uint16
+
+const (
+	// DynamicGasMemory is the memory expansion cost.
+	DynamicGasMemory DynamicGas = 1 << iota
+
+	// DynamicGasCopy is the per-word cost of copying or hashing data, including the init code of CREATE since Shanghai.
+	DynamicGasCopy
+
+	// DynamicGasAccess is the cold account or storage slot access cost of EIP-2929, since Berlin.
+	DynamicGasAccess
+
+	// DynamicGasStorage is the SSTORE cost and refund rules, depending on the original and the current value of the slot.
+	DynamicGasStorage
+
+	// DynamicGasExp is the per-byte cost of the EXP exponent.
+	DynamicGasExp
+
+	// DynamicGasLog is the per-byte cost of the log data.
+	DynamicGasLog
+
+	// DynamicGasCall is the cost of value transfer, new account creation and the gas passed to the callee.
+	DynamicGasCall
+)
+
+var dynamicGasNames = []string{"memory", "copy", "access", "storage", "exp", "log", "call"}
+
+func (g DynamicGas) String() string {
+	if g == 0 {
+		return "none"
+	}
+
+	var names []string
+	for i, name := range dynamicGasNames {
+		if g&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, "|")
+}
+
+// gasChange is the cost of an instruction before the fork that changed it.
+type gasChange struct {
+	until      Fork
+	gas        uint64
+	dynamicGas DynamicGas
+}
+
+// gasHistory lists the costs of the instructions repriced after they were introduced,
+// oldest first. The current cost is the one in InstructionSet.
+var gasHistory = map[Opcode][]gasChange{
+	0x31: { // BALANCE
+		{TangerineWhistle, 20, 0},
+		{Istanbul, 400, 0},
+		{Berlin, 700, 0},
+	},
+	0x3b: { // EXTCODESIZE
+		{TangerineWhistle, 20, 0},
+		{Berlin, 700, 0},
+	},
+	0x3c: { // EXTCODECOPY
+		{TangerineWhistle, 20, DynamicGasMemory | DynamicGasCopy},
+		{Berlin, 700, DynamicGasMemory | DynamicGasCopy},
+	},
+	0x3f: { // EXTCODEHASH
+		{Istanbul, 400, 0},
+		{Berlin, 700, 0},
+	},
+	0x54: { // SLOAD
+		{TangerineWhistle, 50, 0},
+		{Istanbul, 200, 0},
+		{Berlin, 800, 0},
+	},
+	0x55: { // SSTORE
+		{Constantinople, 5000, DynamicGasStorage},
+		{Petersburg, 200, DynamicGasStorage},
+		{Istanbul, 5000, DynamicGasStorage},
+		{Berlin, 800, DynamicGasStorage},
+	},
+	0xf0: { // CREATE
+		{Shanghai, 32000, DynamicGasMemory | DynamicGasCall},
+	},
+	0xf1: { // CALL
+		{TangerineWhistle, 40, DynamicGasMemory | DynamicGasCall},
+		{Berlin, 700, DynamicGasMemory | DynamicGasCall},
+	},
+	0xf2: { // CALLCODE
+		{TangerineWhistle, 40, DynamicGasMemory | DynamicGasCall},
+		{Berlin, 700, DynamicGasMemory | DynamicGasCall},
+	},
+	0xf4: { // DELEGATECALL
+		{TangerineWhistle, 40, DynamicGasMemory | DynamicGasCall},
+		{Berlin, 700, DynamicGasMemory | DynamicGasCall},
+	},
+	0xfa: { // STATICCALL
+		{Berlin, 700, DynamicGasMemory | DynamicGasCall},
+	},
+	0xff: { // SELFDESTRUCT
+		{TangerineWhistle, 0, 0},
+		{Berlin, 5000, DynamicGasCall},
+	},
+}
+
+// applyGasHistory sets the costs of the instructions as they were in the fork.
+func applyGasHistory(set *[0x100]Instruction, f Fork) {
+	for code, changes := range gasHistory {
+		for _, change := range changes {
+			if f < change.until {
+				set[code].Gas = change.gas
+				set[code].DynamicGas = change.dynamicGas
+				break
+			}
+		}
+	}
+}
+
+// StaticGas returns the sum of the static gas costs of the lines, and the union of their dynamic gas components.
+// If the returned DynamicGas is not zero, the sum is the lower bound of the cost of executing the lines.
+func StaticGas(lines []Line) (uint64, DynamicGas) {
+	var (
+		gas     uint64
+		dynamic DynamicGas
+	)
+
+	for _, line := range lines {
+		gas += line.Inst.Gas
+		dynamic |= line.Inst.DynamicGas
+	}
+
+	return gas, dynamic
+}
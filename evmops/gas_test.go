@@ -0,0 +1,92 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmops
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestInstructionGas(t *testing.T) {
+	tests := []struct {
+		fork        Fork
+		code        Opcode
+		wantGas     uint64
+		wantDynamic DynamicGas
+	}{
+		{Frontier, 0x01, 3, 0},
+		{Frontier, 0x54, params.SloadGasFrontier, 0},
+		{TangerineWhistle, 0x54, params.SloadGasEIP150, 0},
+		{Istanbul, 0x54, params.SloadGasEIP2200, 0},
+		{Berlin, 0x54, params.WarmStorageReadCostEIP2929, DynamicGasAccess},
+		{Frontier, 0x55, params.SstoreResetGas, DynamicGasStorage},
+		{Constantinople, 0x55, params.NetSstoreNoopGas, DynamicGasStorage},
+		{Petersburg, 0x55, params.SstoreResetGas, DynamicGasStorage},
+		{Istanbul, 0x55, params.SloadGasEIP2200, DynamicGasStorage},
+		{Cancun, 0x55, params.WarmStorageReadCostEIP2929, DynamicGasStorage | DynamicGasAccess},
+		{Frontier, 0x31, params.BalanceGasFrontier, 0},
+		{Istanbul, 0x31, params.BalanceGasEIP1884, 0},
+		{London, 0x31, params.WarmStorageReadCostEIP2929, DynamicGasAccess},
+		{Homestead, 0xf1, params.CallGasFrontier, DynamicGasMemory | DynamicGasCall},
+		{Byzantium, 0xf1, params.CallGasEIP150, DynamicGasMemory | DynamicGasCall},
+		{Cancun, 0xf1, params.WarmStorageReadCostEIP2929, DynamicGasMemory | DynamicGasAccess | DynamicGasCall},
+		{Frontier, 0xff, 0, 0},
+		{Byzantium, 0xff, params.SelfdestructGasEIP150, DynamicGasCall},
+		{Merge, 0xf0, params.CreateGas, DynamicGasMemory | DynamicGasCall},
+		{Shanghai, 0xf0, params.CreateGas, DynamicGasMemory | DynamicGasCopy | DynamicGasCall},
+		{Cancun, 0xa2, params.LogGas + 2*params.LogTopicGas, DynamicGasMemory | DynamicGasLog},
+		{Cancun, 0x5b, params.JumpdestGas, 0},
+		{Cancun, 0x40, 20, 0},
+		{Cancun, 0x7f, 3, 0},
+		{Cancun, 0x5c, params.WarmStorageReadCostEIP2929, 0},
+	}
+	for _, tt := range tests {
+		inst := ForkInstructionSet(tt.fork)[tt.code]
+		t.Run(tt.fork.String()+"/"+inst.Mnemonic, func(t *testing.T) {
+			if inst.Gas != tt.wantGas {
+				t.Errorf("Gas = %d, want %d", inst.Gas, tt.wantGas)
+			}
+
+			if inst.DynamicGas != tt.wantDynamic {
+				t.Errorf("DynamicGas = %s, want %s", inst.DynamicGas, tt.wantDynamic)
+			}
+		})
+	}
+}
+
+func TestStaticGas(t *testing.T) {
+	set := ForkInstructionSet(Cancun)
+	lines := []Line{
+		{Inst: set[0x60]}, // PUSH1
+		{Inst: set[0x60]}, // PUSH1
+		{Inst: set[0x52]}, // MSTORE
+		{Inst: set[0x54]}, // SLOAD
+		{Inst: set[0x56]}, // JUMP
+	}
+
+	gas, dynamic := StaticGas(lines)
+	if gas != 3+3+3+100+8 {
+		t.Errorf("StaticGas() gas = %d", gas)
+	}
+
+	if dynamic != DynamicGasMemory|DynamicGasAccess {
+		t.Errorf("StaticGas() dynamic = %s", dynamic)
+	}
+
+	if got := dynamic.String(); got != "memory|access" {
+		t.Errorf("DynamicGas.String() = %s", got)
+	}
+}
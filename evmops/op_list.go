@@ -22,86 +22,101 @@ type Instruction struct {
 	InCount      int
 	OutCount     int
 	ConsumeCount int
-	Description  string
+
+	// Gas is the static gas cost of the instruction, charged on every execution.
+	// For the instructions with DynamicGas it is the lower bound of the cost, e.g. the warm access cost
+	// of SLOAD and SSTORE since Berlin.
+	Gas uint64
+
+	// DynamicGas is the set of the gas cost components depending on the execution state
+	DynamicGas DynamicGas
+
+	Description string
 }
 
+// InstructionSet is the instruction set of the latest fork, see ForkInstructionSet for the other forks.
 var InstructionSet = [0x100]Instruction{}
 
 func init() {
-	InstructionSet[0x00] = Instruction{0x00, "STOP", 0, 0, 0, "Halts execution"}
-	InstructionSet[0x01] = Instruction{0x01, "ADD", 2, 1, 0, "Addition operation"}
-	InstructionSet[0x02] = Instruction{0x02, "MUL", 2, 1, 0, "Multiplication operation"}
-	InstructionSet[0x03] = Instruction{0x03, "SUB", 2, 1, 0, "Subtraction operation"}
-	InstructionSet[0x04] = Instruction{0x04, "DIV", 2, 1, 0, "Integer division operation"}
-	InstructionSet[0x05] = Instruction{0x05, "SDIV", 2, 1, 0, "Signed integer division operation"}
-	InstructionSet[0x06] = Instruction{0x06, "MOD", 2, 1, 0, "Modulo remainder operation"}
-	InstructionSet[0x07] = Instruction{0x07, "SMOD", 2, 1, 0, "Signed modulo remainder operation"}
-	InstructionSet[0x08] = Instruction{0x08, "ADDMOD", 3, 1, 0, "Modulo addition operation"}
-	InstructionSet[0x09] = Instruction{0x09, "MULMOD", 3, 1, 0, "Modulo multiplication operation"}
-	InstructionSet[0x0a] = Instruction{0x0a, "EXP", 2, 1, 0, "Exponential operation"}
-	InstructionSet[0x0b] = Instruction{0x0b, "SIGNEXTEND", 2, 1, 0, "Extend length of two's complement signed integer"}
-
-	InstructionSet[0x10] = Instruction{0x10, "LT", 2, 1, 0, "Less-than comparison"}
-	InstructionSet[0x11] = Instruction{0x11, "GT", 2, 1, 0, "Greater-than comparison"}
-	InstructionSet[0x12] = Instruction{0x12, "SLT", 2, 1, 0, "Signed less-than comparison"}
-	InstructionSet[0x13] = Instruction{0x13, "SGT", 2, 1, 0, "Signed greater-than comparison"}
-	InstructionSet[0x14] = Instruction{0x14, "EQ", 2, 1, 0, "Equality comparison"}
-	InstructionSet[0x15] = Instruction{0x15, "ISZERO", 1, 1, 0, "Simple not operator"}
-	InstructionSet[0x16] = Instruction{0x16, "AND", 2, 1, 0, "Bitwise AND operator"}
-	InstructionSet[0x17] = Instruction{0x17, "OR", 2, 1, 0, "Bitwise OR operator"}
-	InstructionSet[0x18] = Instruction{0x18, "XOR", 2, 1, 0, "Bitwise XOR operator"}
-	InstructionSet[0x19] = Instruction{0x19, "NOT", 1, 1, 0, "Bitwise NOT operator"}
-	InstructionSet[0x1a] = Instruction{0x1a, "BYTE", 2, 1, 0, "Retrieve single byte from word"}
-	InstructionSet[0x1b] = Instruction{0x1b, "SHL", 2, 1, 0, "Shift left operation"}
-	InstructionSet[0x1c] = Instruction{0x1c, "SHR", 2, 1, 0, "Logical shift right operation"}
-	InstructionSet[0x1d] = Instruction{0x1d, "SAR", 2, 1, 0, "Arithmetic shift right operation"}
-
-	InstructionSet[0x20] = Instruction{0x20, "SHA3", 2, 1, 0, "Compute Keccak-256 hash"}
-
-	InstructionSet[0x30] = Instruction{0x30, "ADDRESS", 0, 1, 0, "Get address of currently executing account"}
-	InstructionSet[0x31] = Instruction{0x31, "BALANCE", 1, 1, 0, "Get balance of the given account"}
-	InstructionSet[0x32] = Instruction{0x32, "ORIGIN", 0, 1, 0, "Get execution origination address"}
-	InstructionSet[0x33] = Instruction{0x33, "CALLER", 0, 1, 0, "Get caller address"}
-	InstructionSet[0x34] = Instruction{0x34, "CALLVALUE", 0, 1, 0, "Get deposited value by the instruction/transaction responsible for this execution"}
-	InstructionSet[0x35] = Instruction{0x35, "CALLDATALOAD", 1, 1, 0, "Get input data of current environment"}
-	InstructionSet[0x36] = Instruction{0x36, "CALLDATASIZE", 0, 1, 0, "Get size of input data in current environment"}
-	InstructionSet[0x37] = Instruction{0x37, "CALLDATACOPY", 3, 0, 0, "Copy input data in current environment to memory"}
-	InstructionSet[0x38] = Instruction{0x38, "CODESIZE", 0, 1, 0, "Get size of running code in current environment"}
-	InstructionSet[0x39] = Instruction{0x39, "CODECOPY", 3, 0, 0, "Copy code running in current environment to memory"}
-	InstructionSet[0x3a] = Instruction{0x3a, "GASPRICE", 0, 1, 0, "Get price of gas in current environment"}
-	InstructionSet[0x3b] = Instruction{0x3b, "EXTCODESIZE", 1, 1, 0, "Get size of an account's code"}
-	InstructionSet[0x3c] = Instruction{0x3c, "EXTCODECOPY", 4, 0, 0, "Copy an account's code to memory"}
-	InstructionSet[0x3d] = Instruction{0x3d, "RETURNDATASIZE", 0, 1, 0, "Get size of output data from the previous call from the current environment"}
-	InstructionSet[0x3e] = Instruction{0x3e, "RETURNDATACOPY", 3, 0, 0, "Copy output data from the previous call to memory"}
-	InstructionSet[0x3f] = Instruction{0x3f, "EXTCODEHASH", 1, 1, 0, "Get the code hash of an account"}
-
-	InstructionSet[0x40] = Instruction{0x40, "BLOCKHASH", 1, 1, 0, "Get the hash of one of the 256 most recent complete blocks"}
-	InstructionSet[0x41] = Instruction{0x41, "COINBASE", 0, 1, 0, "Get the block's beneficiary address"}
-	InstructionSet[0x42] = Instruction{0x42, "TIMESTAMP", 0, 1, 0, "Get the block's timestamp"}
-	InstructionSet[0x43] = Instruction{0x43, "NUMBER", 0, 1, 0, "Get the block's number"}
-	InstructionSet[0x44] = Instruction{0x44, "DIFFICULTY", 0, 1, 0, "Get the block's difficulty"}
-	InstructionSet[0x45] = Instruction{0x45, "GASLIMIT", 0, 1, 0, "Get the block's gas limit"}
-	InstructionSet[0x46] = Instruction{0x46, "CHAINID", 0, 1, 0, "Get the chain id"}
-	InstructionSet[0x47] = Instruction{0x47, "SELFBALANCE", 0, 1, 0, "Get the balance of the current contract"}
-	InstructionSet[0x48] = Instruction{0x48, "BASEFEE", 0, 1, 0, "Get the base fee of the current block"}
-
-	InstructionSet[0x50] = Instruction{0x50, "POP", 1, 0, 0, "Remove item from stack"}
-	InstructionSet[0x51] = Instruction{0x51, "MLOAD", 1, 1, 0, "Load word from memory"}
-	InstructionSet[0x52] = Instruction{0x52, "MSTORE", 2, 0, 0, "Save word to memory"}
-	InstructionSet[0x53] = Instruction{0x53, "MSTORE8", 2, 0, 0, "Save byte to memory"}
-	InstructionSet[0x54] = Instruction{0x54, "SLOAD", 1, 1, 0, "Load word from storage"}
-	InstructionSet[0x55] = Instruction{0x55, "SSTORE", 2, 0, 0, "Store word to storage"}
-	InstructionSet[0x56] = Instruction{0x56, "JUMP", 1, 0, 0, "Alter the program counter"}
-	InstructionSet[0x57] = Instruction{0x57, "JUMPI", 2, 0, 0, "Conditionally alter the program counter"}
-	InstructionSet[0x58] = Instruction{0x58, "PC", 0, 1, 0, "Get the value of the program counter prior to the increment corresponding to this instruction"}
-	InstructionSet[0x59] = Instruction{0x59, "MSIZE", 0, 1, 0, "Get the size of active memory in bytes"}
-	InstructionSet[0x5a] = Instruction{0x5a, "GAS", 0, 1, 0, "Get the amount of available gas, including the corresponding reduction for the cost of this instruction"}
-	InstructionSet[0x5b] = Instruction{0x5b, "JUMPDEST", 0, 0, 0, "Mark a valid destination for jumps"}
-	InstructionSet[0x5f] = Instruction{0x5f, "PUSH0", 0, 1, 0, "EIP-3855: Pushes the constant value 0 onto the stack"}
+	InstructionSet[0x00] = Instruction{Code: 0x00, Mnemonic: "STOP", InCount: 0, OutCount: 0, Gas: 0, Description: "Halts execution"}
+	InstructionSet[0x01] = Instruction{Code: 0x01, Mnemonic: "ADD", InCount: 2, OutCount: 1, Gas: 3, Description: "Addition operation"}
+	InstructionSet[0x02] = Instruction{Code: 0x02, Mnemonic: "MUL", InCount: 2, OutCount: 1, Gas: 5, Description: "Multiplication operation"}
+	InstructionSet[0x03] = Instruction{Code: 0x03, Mnemonic: "SUB", InCount: 2, OutCount: 1, Gas: 3, Description: "Subtraction operation"}
+	InstructionSet[0x04] = Instruction{Code: 0x04, Mnemonic: "DIV", InCount: 2, OutCount: 1, Gas: 5, Description: "Integer division operation"}
+	InstructionSet[0x05] = Instruction{Code: 0x05, Mnemonic: "SDIV", InCount: 2, OutCount: 1, Gas: 5, Description: "Signed integer division operation"}
+	InstructionSet[0x06] = Instruction{Code: 0x06, Mnemonic: "MOD", InCount: 2, OutCount: 1, Gas: 5, Description: "Modulo remainder operation"}
+	InstructionSet[0x07] = Instruction{Code: 0x07, Mnemonic: "SMOD", InCount: 2, OutCount: 1, Gas: 5, Description: "Signed modulo remainder operation"}
+	InstructionSet[0x08] = Instruction{Code: 0x08, Mnemonic: "ADDMOD", InCount: 3, OutCount: 1, Gas: 8, Description: "Modulo addition operation"}
+	InstructionSet[0x09] = Instruction{Code: 0x09, Mnemonic: "MULMOD", InCount: 3, OutCount: 1, Gas: 8, Description: "Modulo multiplication operation"}
+	InstructionSet[0x0a] = Instruction{Code: 0x0a, Mnemonic: "EXP", InCount: 2, OutCount: 1, Gas: 10, DynamicGas: DynamicGasExp, Description: "Exponential operation"}
+	InstructionSet[0x0b] = Instruction{Code: 0x0b, Mnemonic: "SIGNEXTEND", InCount: 2, OutCount: 1, Gas: 5, Description: "Extend length of two's complement signed integer"}
+
+	InstructionSet[0x10] = Instruction{Code: 0x10, Mnemonic: "LT", InCount: 2, OutCount: 1, Gas: 3, Description: "Less-than comparison"}
+	InstructionSet[0x11] = Instruction{Code: 0x11, Mnemonic: "GT", InCount: 2, OutCount: 1, Gas: 3, Description: "Greater-than comparison"}
+	InstructionSet[0x12] = Instruction{Code: 0x12, Mnemonic: "SLT", InCount: 2, OutCount: 1, Gas: 3, Description: "Signed less-than comparison"}
+	InstructionSet[0x13] = Instruction{Code: 0x13, Mnemonic: "SGT", InCount: 2, OutCount: 1, Gas: 3, Description: "Signed greater-than comparison"}
+	InstructionSet[0x14] = Instruction{Code: 0x14, Mnemonic: "EQ", InCount: 2, OutCount: 1, Gas: 3, Description: "Equality comparison"}
+	InstructionSet[0x15] = Instruction{Code: 0x15, Mnemonic: "ISZERO", InCount: 1, OutCount: 1, Gas: 3, Description: "Simple not operator"}
+	InstructionSet[0x16] = Instruction{Code: 0x16, Mnemonic: "AND", InCount: 2, OutCount: 1, Gas: 3, Description: "Bitwise AND operator"}
+	InstructionSet[0x17] = Instruction{Code: 0x17, Mnemonic: "OR", InCount: 2, OutCount: 1, Gas: 3, Description: "Bitwise OR operator"}
+	InstructionSet[0x18] = Instruction{Code: 0x18, Mnemonic: "XOR", InCount: 2, OutCount: 1, Gas: 3, Description: "Bitwise XOR operator"}
+	InstructionSet[0x19] = Instruction{Code: 0x19, Mnemonic: "NOT", InCount: 1, OutCount: 1, Gas: 3, Description: "Bitwise NOT operator"}
+	InstructionSet[0x1a] = Instruction{Code: 0x1a, Mnemonic: "BYTE", InCount: 2, OutCount: 1, Gas: 3, Description: "Retrieve single byte from word"}
+	InstructionSet[0x1b] = Instruction{Code: 0x1b, Mnemonic: "SHL", InCount: 2, OutCount: 1, Gas: 3, Description: "Shift left operation"}
+	InstructionSet[0x1c] = Instruction{Code: 0x1c, Mnemonic: "SHR", InCount: 2, OutCount: 1, Gas: 3, Description: "Logical shift right operation"}
+	InstructionSet[0x1d] = Instruction{Code: 0x1d, Mnemonic: "SAR", InCount: 2, OutCount: 1, Gas: 3, Description: "Arithmetic shift right operation"}
+
+	InstructionSet[0x20] = Instruction{Code: 0x20, Mnemonic: "SHA3", InCount: 2, OutCount: 1, Gas: 30, DynamicGas: DynamicGasMemory | DynamicGasCopy, Description: "Compute Keccak-256 hash"}
+
+	InstructionSet[0x30] = Instruction{Code: 0x30, Mnemonic: "ADDRESS", InCount: 0, OutCount: 1, Gas: 2, Description: "Get address of currently executing account"}
+	InstructionSet[0x31] = Instruction{Code: 0x31, Mnemonic: "BALANCE", InCount: 1, OutCount: 1, Gas: 100, DynamicGas: DynamicGasAccess, Description: "Get balance of the given account"}
+	InstructionSet[0x32] = Instruction{Code: 0x32, Mnemonic: "ORIGIN", InCount: 0, OutCount: 1, Gas: 2, Description: "Get execution origination address"}
+	InstructionSet[0x33] = Instruction{Code: 0x33, Mnemonic: "CALLER", InCount: 0, OutCount: 1, Gas: 2, Description: "Get caller address"}
+	InstructionSet[0x34] = Instruction{Code: 0x34, Mnemonic: "CALLVALUE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get deposited value by the instruction/transaction responsible for this execution"}
+	InstructionSet[0x35] = Instruction{Code: 0x35, Mnemonic: "CALLDATALOAD", InCount: 1, OutCount: 1, Gas: 3, Description: "Get input data of current environment"}
+	InstructionSet[0x36] = Instruction{Code: 0x36, Mnemonic: "CALLDATASIZE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get size of input data in current environment"}
+	InstructionSet[0x37] = Instruction{Code: 0x37, Mnemonic: "CALLDATACOPY", InCount: 3, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory | DynamicGasCopy, Description: "Copy input data in current environment to memory"}
+	InstructionSet[0x38] = Instruction{Code: 0x38, Mnemonic: "CODESIZE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get size of running code in current environment"}
+	InstructionSet[0x39] = Instruction{Code: 0x39, Mnemonic: "CODECOPY", InCount: 3, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory | DynamicGasCopy, Description: "Copy code running in current environment to memory"}
+	InstructionSet[0x3a] = Instruction{Code: 0x3a, Mnemonic: "GASPRICE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get price of gas in current environment"}
+	InstructionSet[0x3b] = Instruction{Code: 0x3b, Mnemonic: "EXTCODESIZE", InCount: 1, OutCount: 1, Gas: 100, DynamicGas: DynamicGasAccess, Description: "Get size of an account's code"}
+	InstructionSet[0x3c] = Instruction{Code: 0x3c, Mnemonic: "EXTCODECOPY", InCount: 4, OutCount: 0, Gas: 100, DynamicGas: DynamicGasMemory | DynamicGasCopy | DynamicGasAccess, Description: "Copy an account's code to memory"}
+	InstructionSet[0x3d] = Instruction{Code: 0x3d, Mnemonic: "RETURNDATASIZE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get size of output data from the previous call from the current environment"}
+	InstructionSet[0x3e] = Instruction{Code: 0x3e, Mnemonic: "RETURNDATACOPY", InCount: 3, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory | DynamicGasCopy, Description: "Copy output data from the previous call to memory"}
+	InstructionSet[0x3f] = Instruction{Code: 0x3f, Mnemonic: "EXTCODEHASH", InCount: 1, OutCount: 1, Gas: 100, DynamicGas: DynamicGasAccess, Description: "Get the code hash of an account"}
+
+	InstructionSet[0x40] = Instruction{Code: 0x40, Mnemonic: "BLOCKHASH", InCount: 1, OutCount: 1, Gas: 20, Description: "Get the hash of one of the 256 most recent complete blocks"}
+	InstructionSet[0x41] = Instruction{Code: 0x41, Mnemonic: "COINBASE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the block's beneficiary address"}
+	InstructionSet[0x42] = Instruction{Code: 0x42, Mnemonic: "TIMESTAMP", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the block's timestamp"}
+	InstructionSet[0x43] = Instruction{Code: 0x43, Mnemonic: "NUMBER", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the block's number"}
+	InstructionSet[0x44] = Instruction{Code: 0x44, Mnemonic: "PREVRANDAO", InCount: 0, OutCount: 1, Gas: 2, Description: "EIP-4399: Get the previous block's RANDAO mix, the block's difficulty before the Merge"}
+	InstructionSet[0x45] = Instruction{Code: 0x45, Mnemonic: "GASLIMIT", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the block's gas limit"}
+	InstructionSet[0x46] = Instruction{Code: 0x46, Mnemonic: "CHAINID", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the chain id"}
+	InstructionSet[0x47] = Instruction{Code: 0x47, Mnemonic: "SELFBALANCE", InCount: 0, OutCount: 1, Gas: 5, Description: "Get the balance of the current contract"}
+	InstructionSet[0x48] = Instruction{Code: 0x48, Mnemonic: "BASEFEE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the base fee of the current block"}
+	InstructionSet[0x49] = Instruction{Code: 0x49, Mnemonic: "BLOBHASH", InCount: 1, OutCount: 1, Gas: 3, Description: "EIP-4844: Get the versioned hash of the transaction's blob"}
+	InstructionSet[0x4a] = Instruction{Code: 0x4a, Mnemonic: "BLOBBASEFEE", InCount: 0, OutCount: 1, Gas: 2, Description: "EIP-7516: Get the blob base fee of the current block"}
+
+	InstructionSet[0x50] = Instruction{Code: 0x50, Mnemonic: "POP", InCount: 1, OutCount: 0, Gas: 2, Description: "Remove item from stack"}
+	InstructionSet[0x51] = Instruction{Code: 0x51, Mnemonic: "MLOAD", InCount: 1, OutCount: 1, Gas: 3, DynamicGas: DynamicGasMemory, Description: "Load word from memory"}
+	InstructionSet[0x52] = Instruction{Code: 0x52, Mnemonic: "MSTORE", InCount: 2, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory, Description: "Save word to memory"}
+	InstructionSet[0x53] = Instruction{Code: 0x53, Mnemonic: "MSTORE8", InCount: 2, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory, Description: "Save byte to memory"}
+	InstructionSet[0x54] = Instruction{Code: 0x54, Mnemonic: "SLOAD", InCount: 1, OutCount: 1, Gas: 100, DynamicGas: DynamicGasAccess, Description: "Load word from storage"}
+	InstructionSet[0x55] = Instruction{Code: 0x55, Mnemonic: "SSTORE", InCount: 2, OutCount: 0, Gas: 100, DynamicGas: DynamicGasStorage | DynamicGasAccess, Description: "Store word to storage"}
+	InstructionSet[0x56] = Instruction{Code: 0x56, Mnemonic: "JUMP", InCount: 1, OutCount: 0, Gas: 8, Description: "Alter the program counter"}
+	InstructionSet[0x57] = Instruction{Code: 0x57, Mnemonic: "JUMPI", InCount: 2, OutCount: 0, Gas: 10, Description: "Conditionally alter the program counter"}
+	InstructionSet[0x58] = Instruction{Code: 0x58, Mnemonic: "PC", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the value of the program counter prior to the increment corresponding to this instruction"}
+	InstructionSet[0x59] = Instruction{Code: 0x59, Mnemonic: "MSIZE", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the size of active memory in bytes"}
+	InstructionSet[0x5a] = Instruction{Code: 0x5a, Mnemonic: "GAS", InCount: 0, OutCount: 1, Gas: 2, Description: "Get the amount of available gas, including the corresponding reduction for the cost of this instruction"}
+	InstructionSet[0x5b] = Instruction{Code: 0x5b, Mnemonic: "JUMPDEST", InCount: 0, OutCount: 0, Gas: 1, Description: "Mark a valid destination for jumps"}
+	InstructionSet[0x5c] = Instruction{Code: 0x5c, Mnemonic: "TLOAD", InCount: 1, OutCount: 1, Gas: 100, Description: "EIP-1153: Load word from transient storage"}
+	InstructionSet[0x5d] = Instruction{Code: 0x5d, Mnemonic: "TSTORE", InCount: 2, OutCount: 0, Gas: 100, Description: "EIP-1153: Save word to transient storage"}
+	InstructionSet[0x5e] = Instruction{Code: 0x5e, Mnemonic: "MCOPY", InCount: 3, OutCount: 0, Gas: 3, DynamicGas: DynamicGasMemory | DynamicGasCopy, Description: "EIP-5656: Copy memory areas"}
+	InstructionSet[0x5f] = Instruction{Code: 0x5f, Mnemonic: "PUSH0", InCount: 0, OutCount: 1, Gas: 2, Description: "EIP-3855: Pushes the constant value 0 onto the stack"}
 
 	for i := 0; i < 32; i++ {
 		code := Opcode(0x60 + i)
-		InstructionSet[code] = Instruction{code, fmt.Sprintf("PUSH%d", i+1), 0, 1, i + 1, fmt.Sprintf("Place %d-byte item on stack", i+1)}
+		InstructionSet[code] = Instruction{Code: code, Mnemonic: fmt.Sprintf("PUSH%d", i+1), InCount: 0, OutCount: 1, ConsumeCount: i + 1, Gas: 3, Description: fmt.Sprintf("Place %d-byte item on stack", i+1)}
 	}
 
 	ord := func(i int) string {
@@ -119,34 +134,40 @@ func init() {
 
 	for i := 0; i < 16; i++ {
 		code := Opcode(0x80 + i)
-		InstructionSet[code] = Instruction{code, fmt.Sprintf("DUP%d", i+1), i + 1, i + 2, 0, fmt.Sprintf("Duplicate %s stack item", ord(i+1))}
+		InstructionSet[code] = Instruction{Code: code, Mnemonic: fmt.Sprintf("DUP%d", i+1), InCount: i + 1, OutCount: i + 2, Gas: 3, Description: fmt.Sprintf("Duplicate %s stack item", ord(i+1))}
 	}
 
 	for i := 0; i < 16; i++ {
 		code := Opcode(0x90 + i)
-		InstructionSet[code] = Instruction{code, fmt.Sprintf("SWAP%d", i+1), i + 2, i + 2, 0, fmt.Sprintf("Exchange 1st and %s stack items", ord(i+1))}
+		InstructionSet[code] = Instruction{Code: code, Mnemonic: fmt.Sprintf("SWAP%d", i+1), InCount: i + 2, OutCount: i + 2, Gas: 3, Description: fmt.Sprintf("Exchange 1st and %s stack items", ord(i+1))}
 	}
 
-	InstructionSet[0xa0] = Instruction{0xa0, "LOG0", 2, 0, 0, "Append log record with no topics"}
-	InstructionSet[0xa1] = Instruction{0xa1, "LOG1", 3, 0, 0, "Append log record with one topic"}
-	InstructionSet[0xa2] = Instruction{0xa2, "LOG2", 4, 0, 0, "Append log record with two topics"}
-	InstructionSet[0xa3] = Instruction{0xa3, "LOG3", 5, 0, 0, "Append log record with three topics"}
-	InstructionSet[0xa4] = Instruction{0xa4, "LOG4", 6, 0, 0, "Append log record with four topics"}
-
-	InstructionSet[0xf0] = Instruction{0xf0, "CREATE", 3, 1, 0, "Create a new account with associated code"}
-	InstructionSet[0xf1] = Instruction{0xf1, "CALL", 7, 1, 0, "Message-call into an account"}
-	InstructionSet[0xf2] = Instruction{0xf2, "CALLCODE", 7, 1, 0, "Message-call into this account with an alternative account's code"}
-	InstructionSet[0xf3] = Instruction{0xf3, "RETURN", 2, 0, 0, "Halt execution returning output data"}
-	InstructionSet[0xf4] = Instruction{0xf4, "DELEGATECALL", 6, 1, 0, "Message-call into this account with an alternative account's code, but persisting the current values for _sender_ and _value_"}
-	InstructionSet[0xf5] = Instruction{0xf5, "CREATE2", 4, 1, 0, "Create a new account with associated code, where the creation address is deterministically generated from the sender address, the salt and the init code (eip-1014)"}
-	InstructionSet[0xfa] = Instruction{0xfa, "STATICCALL", 6, 1, 0, "Message-call into an account, but disallow state modifications (eip-214)"}
-	InstructionSet[0xfd] = Instruction{0xfd, "REVERT", 2, 0, 0, "Halt execution and revert state changes, without consuming all provided gas and providing a reason (eip-140)"}
-	InstructionSet[0xfe] = Instruction{0xfe, "INVALID", 0, 0, 0, "Designated invalid instruction (eip-141)"}
-	InstructionSet[0xff] = Instruction{0xff, "SUICIDE", 1, 0, 0, "Halt execution and register account for later deletion"}
+	InstructionSet[0xa0] = Instruction{Code: 0xa0, Mnemonic: "LOG0", InCount: 2, OutCount: 0, Gas: 375, DynamicGas: DynamicGasMemory | DynamicGasLog, Description: "Append log record with no topics"}
+	InstructionSet[0xa1] = Instruction{Code: 0xa1, Mnemonic: "LOG1", InCount: 3, OutCount: 0, Gas: 750, DynamicGas: DynamicGasMemory | DynamicGasLog, Description: "Append log record with one topic"}
+	InstructionSet[0xa2] = Instruction{Code: 0xa2, Mnemonic: "LOG2", InCount: 4, OutCount: 0, Gas: 1125, DynamicGas: DynamicGasMemory | DynamicGasLog, Description: "Append log record with two topics"}
+	InstructionSet[0xa3] = Instruction{Code: 0xa3, Mnemonic: "LOG3", InCount: 5, OutCount: 0, Gas: 1500, DynamicGas: DynamicGasMemory | DynamicGasLog, Description: "Append log record with three topics"}
+	InstructionSet[0xa4] = Instruction{Code: 0xa4, Mnemonic: "LOG4", InCount: 6, OutCount: 0, Gas: 1875, DynamicGas: DynamicGasMemory | DynamicGasLog, Description: "Append log record with four topics"}
+
+	InstructionSet[0xf0] = Instruction{Code: 0xf0, Mnemonic: "CREATE", InCount: 3, OutCount: 1, Gas: 32000, DynamicGas: DynamicGasMemory | DynamicGasCopy | DynamicGasCall, Description: "Create a new account with associated code"}
+	InstructionSet[0xf1] = Instruction{Code: 0xf1, Mnemonic: "CALL", InCount: 7, OutCount: 1, Gas: 100, DynamicGas: DynamicGasMemory | DynamicGasAccess | DynamicGasCall, Description: "Message-call into an account"}
+	InstructionSet[0xf2] = Instruction{Code: 0xf2, Mnemonic: "CALLCODE", InCount: 7, OutCount: 1, Gas: 100, DynamicGas: DynamicGasMemory | DynamicGasAccess | DynamicGasCall, Description: "Message-call into this account with an alternative account's code"}
+	InstructionSet[0xf3] = Instruction{Code: 0xf3, Mnemonic: "RETURN", InCount: 2, OutCount: 0, Gas: 0, DynamicGas: DynamicGasMemory, Description: "Halt execution returning output data"}
+	InstructionSet[0xf4] = Instruction{Code: 0xf4, Mnemonic: "DELEGATECALL", InCount: 6, OutCount: 1, Gas: 100, DynamicGas: DynamicGasMemory | DynamicGasAccess | DynamicGasCall, Description: "Message-call into this account with an alternative account's code, but persisting the current values for _sender_ and _value_"}
+	InstructionSet[0xf5] = Instruction{Code: 0xf5, Mnemonic: "CREATE2", InCount: 4, OutCount: 1, Gas: 32000, DynamicGas: DynamicGasMemory | DynamicGasCopy | DynamicGasCall, Description: "Create a new account with associated code, where the creation address is deterministically generated from the sender address, the salt and the init code (eip-1014)"}
+	InstructionSet[0xfa] = Instruction{Code: 0xfa, Mnemonic: "STATICCALL", InCount: 6, OutCount: 1, Gas: 100, DynamicGas: DynamicGasMemory | DynamicGasAccess | DynamicGasCall, Description: "Message-call into an account, but disallow state modifications (eip-214)"}
+	InstructionSet[0xfd] = Instruction{Code: 0xfd, Mnemonic: "REVERT", InCount: 2, OutCount: 0, Gas: 0, DynamicGas: DynamicGasMemory, Description: "Halt execution and revert state changes, without consuming all provided gas and providing a reason (eip-140)"}
+	InstructionSet[0xfe] = Instruction{Code: 0xfe, Mnemonic: "INVALID", InCount: 0, OutCount: 0, Gas: 0, Description: "Designated invalid instruction (eip-141)"}
+	InstructionSet[0xff] = Instruction{Code: 0xff, Mnemonic: "SELFDESTRUCT", InCount: 1, OutCount: 0, Gas: 5000, DynamicGas: DynamicGasAccess | DynamicGasCall, Description: "Halt execution and register account for later deletion"}
 
 	for i := 0; i < 0x100; i++ {
 		if InstructionSet[i].Mnemonic == "" {
-			InstructionSet[i] = Instruction{Opcode(i), fmt.Sprintf("__UNDEFINED_INSTRUCTION(%02x)", i), 0, 0, 0, "Undefined instruction"}
+			InstructionSet[i] = undefinedInstruction(Opcode(i))
 		}
 	}
+
+	initForkInstructionSets()
+}
+
+func undefinedInstruction(code Opcode) Instruction {
+	return Instruction{Code: code, Mnemonic: fmt.Sprintf("__UNDEFINED_INSTRUCTION(%02x)", code), Description: "Undefined instruction"}
 }
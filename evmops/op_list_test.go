@@ -21,11 +21,13 @@ import (
 	"github.com/ethereum/go-ethereum/core/vm"
 )
 
+// TestListCompleteness compares the Shanghai instruction set with the opcodes known to geth.
 func TestListCompleteness(t *testing.T) {
+	set := ForkInstructionSet(Shanghai)
 	for i := 0; i < 256; i++ {
 
 		op := vm.OpCode(i)
-		instr := InstructionSet[i]
+		instr := set[i]
 		instrUndefined := strings.Contains(instr.Mnemonic, "__UNDEFINED_INSTRUCTION")
 
 		if strings.Contains(op.String(), "not defined") {
@@ -40,3 +42,45 @@ func TestListCompleteness(t *testing.T) {
 		}
 	}
 }
+
+func TestForkInstructionSet(t *testing.T) {
+	tests := []struct {
+		fork       Fork
+		code       Opcode
+		wantActive bool
+		wantName   string
+	}{
+		{Frontier, 0x01, true, "ADD"},
+		{Frontier, 0xf4, false, ""},
+		{Homestead, 0xf4, true, "DELEGATECALL"},
+		{Byzantium, 0x1c, false, ""},
+		{Constantinople, 0x1c, true, "SHR"},
+		{London, 0x44, true, "DIFFICULTY"},
+		{Merge, 0x44, true, "PREVRANDAO"},
+		{Merge, 0x5f, false, ""},
+		{Shanghai, 0x5f, true, "PUSH0"},
+		{Shanghai, 0x5c, false, ""},
+		{Cancun, 0x5c, true, "TLOAD"},
+		{Cancun, 0x4a, true, "BLOBBASEFEE"},
+		{Prague, 0xff, true, "SELFDESTRUCT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.fork.String()+"/"+InstructionSet[tt.code].Mnemonic, func(t *testing.T) {
+			if got := tt.fork.IsActive(tt.code); got != tt.wantActive {
+				t.Errorf("IsActive() = %v, want %v", got, tt.wantActive)
+			}
+
+			if tt.wantName == "" {
+				return
+			}
+
+			if got := ForkInstructionSet(tt.fork)[tt.code].Mnemonic; got != tt.wantName {
+				t.Errorf("Mnemonic = %q, want %q", got, tt.wantName)
+			}
+		})
+	}
+
+	if *ForkInstructionSet(LatestFork) != InstructionSet {
+		t.Error("the latest fork instruction set differs from InstructionSet")
+	}
+}
@@ -18,6 +18,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"strings"
+
+	"github.com/kirillDanshin/evmtools/evmfuncs"
 )
 
 type Opcode byte
@@ -32,6 +34,12 @@ type Line struct {
 
 	// Comment is a comment about the operation, that should be displayed on the same line as the operation.
 	Comment string
+
+	// BestSignature is the most likely text signature of the pushed 4-byte selector, if any.
+	BestSignature string
+
+	// Signatures is the list of scored candidate signatures of the pushed 4-byte selector, best first.
+	Signatures []evmfuncs.ScoredSignature
 }
 
 func (l *Line) String() string {
@@ -45,9 +53,7 @@ func (l *Line) String() string {
 		str += " " + joinAsHex(l.Args, ", ")
 	}
 
-	l.Comment += fmt.Sprintf(" pc=%d", l.ProgramCounter)
-
-	str += " ; " + l.Comment
+	str += " ; " + l.Comment + fmt.Sprintf(" pc=%d", l.ProgramCounter)
 
 	return str
 }
@@ -0,0 +1,37 @@
+// Copyright 2022 Kyrylo Danshyn
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//   http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package evmops
+
+import "testing"
+
+func TestLine_String(t *testing.T) {
+	line := Line{
+		Inst:           InstructionSet[0x63],
+		ProgramCounter: 28,
+		Args:           []string{"\xa9\x05\x9c\xbb"},
+		Comment:        "transfer(address,uint256)",
+	}
+
+	const want = "PUSH4 a9059cbb ; transfer(address,uint256) pc=28"
+	for i := 0; i < 2; i++ {
+		if got := line.String(); got != want {
+			t.Errorf("Line.String() = %q, want %q", got, want)
+		}
+	}
+
+	if line.Comment != "transfer(address,uint256)" {
+		t.Errorf("Line.String() modified the comment: %q", line.Comment)
+	}
+}
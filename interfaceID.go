@@ -0,0 +1,142 @@
+package evmtools
+
+import (
+	"sort"
+	"sync"
+)
+
+// InterfaceID returns the ERC-165 interface ID of the functions with the given canonical signatures,
+// i.e. XOR of their selectors.
+func InterfaceID(signatures ...string) []byte {
+	id := make([]byte, 4)
+	for _, sig := range signatures {
+		for i, b := range MethodID(sig) {
+			id[i] ^= b
+		}
+	}
+
+	return id
+}
+
+// KnownInterface is a registered ERC-165 interface.
+type KnownInterface struct {
+	Name string
+	ID   [4]byte
+
+	// Signatures are the functions of the interface, empty if the ID is not derived from them,
+	// e.g. for ERC-4906.
+	Signatures []string
+}
+
+var (
+	interfacesMu sync.RWMutex
+	interfaces   = map[[4]byte]KnownInterface{}
+)
+
+// RegisterInterface registers the interface with the ID computed from the functions.
+func RegisterInterface(name string, signatures ...string) KnownInterface {
+	var id [4]byte
+	copy(id[:], InterfaceID(signatures...))
+
+	iface := KnownInterface{Name: name, ID: id, Signatures: signatures}
+	RegisterInterfaceID(iface)
+
+	return iface
+}
+
+// RegisterInterfaceID registers the interface, replacing the one with the same ID.
+func RegisterInterfaceID(iface KnownInterface) {
+	interfacesMu.Lock()
+	defer interfacesMu.Unlock()
+
+	interfaces[iface.ID] = iface
+}
+
+// LookupInterface returns the registered interface with the given ID.
+func LookupInterface(id []byte) (KnownInterface, bool) {
+	var key [4]byte
+	if len(id) != len(key) {
+		return KnownInterface{}, false
+	}
+	copy(key[:], id)
+
+	interfacesMu.RLock()
+	defer interfacesMu.RUnlock()
+
+	iface, ok := interfaces[key]
+
+	return iface, ok
+}
+
+// KnownInterfaces returns the registered interfaces sorted by name.
+func KnownInterfaces() []KnownInterface {
+	interfacesMu.RLock()
+	defer interfacesMu.RUnlock()
+
+	list := make([]KnownInterface, 0, len(interfaces))
+	for _, iface := range interfaces {
+		list = append(list, iface)
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list
+}
+
+func init() {
+	RegisterInterface("ERC165", "supportsInterface(bytes4)")
+	RegisterInterface("ERC20",
+		"totalSupply()",
+		"balanceOf(address)",
+		"transfer(address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"allowance(address,address)",
+	)
+	RegisterInterface("ERC721",
+		"balanceOf(address)",
+		"ownerOf(uint256)",
+		"safeTransferFrom(address,address,uint256,bytes)",
+		"safeTransferFrom(address,address,uint256)",
+		"transferFrom(address,address,uint256)",
+		"approve(address,uint256)",
+		"setApprovalForAll(address,bool)",
+		"getApproved(uint256)",
+		"isApprovedForAll(address,address)",
+	)
+	RegisterInterface("ERC721Metadata", "name()", "symbol()", "tokenURI(uint256)")
+	RegisterInterface("ERC721Enumerable", "totalSupply()", "tokenOfOwnerByIndex(address,uint256)", "tokenByIndex(uint256)")
+	RegisterInterface("ERC721Receiver", "onERC721Received(address,address,uint256,bytes)")
+	RegisterInterface("ERC1155",
+		"safeTransferFrom(address,address,uint256,uint256,bytes)",
+		"safeBatchTransferFrom(address,address,uint256[],uint256[],bytes)",
+		"balanceOf(address,uint256)",
+		"balanceOfBatch(address[],uint256[])",
+		"setApprovalForAll(address,bool)",
+		"isApprovedForAll(address,address)",
+	)
+	RegisterInterface("ERC1155MetadataURI", "uri(uint256)")
+	RegisterInterface("ERC1155Receiver",
+		"onERC1155Received(address,address,uint256,uint256,bytes)",
+		"onERC1155BatchReceived(address,address,uint256[],uint256[],bytes)",
+	)
+	RegisterInterface("ERC1363",
+		"transferAndCall(address,uint256)",
+		"transferAndCall(address,uint256,bytes)",
+		"transferFromAndCall(address,address,uint256)",
+		"transferFromAndCall(address,address,uint256,bytes)",
+		"approveAndCall(address,uint256)",
+		"approveAndCall(address,uint256,bytes)",
+	)
+	RegisterInterface("ERC2981", "royaltyInfo(uint256,uint256)")
+	RegisterInterface("AccessControl",
+		"hasRole(bytes32,address)",
+		"getRoleAdmin(bytes32)",
+		"grantRole(bytes32,address)",
+		"revokeRole(bytes32,address)",
+		"renounceRole(bytes32,address)",
+	)
+	RegisterInterface("AccessControlEnumerable", "getRoleMember(bytes32,uint256)", "getRoleMemberCount(bytes32)")
+	RegisterInterfaceID(KnownInterface{Name: "ERC4906", ID: [4]byte{0x49, 0x06, 0x49, 0x06}})
+}
@@ -0,0 +1,44 @@
+package evmtools
+
+import (
+	"encoding/hex"
+	"testing"
+)
+
+func TestInterfaceID(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"ERC165", "01ffc9a7"},
+		{"ERC20", "36372b07"},
+		{"ERC721", "80ac58cd"},
+		{"ERC721Metadata", "5b5e139f"},
+		{"ERC721Enumerable", "780e9d63"},
+		{"ERC721Receiver", "150b7a02"},
+		{"ERC1155", "d9b67a26"},
+		{"ERC1155MetadataURI", "0e89341c"},
+		{"ERC1155Receiver", "4e2312e0"},
+		{"ERC1363", "b0202a11"},
+		{"ERC2981", "2a55205a"},
+		{"AccessControl", "7965db0b"},
+		{"AccessControlEnumerable", "5a05180f"},
+		{"ERC4906", "49064906"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			id, _ := hex.DecodeString(tt.want)
+
+			iface, ok := LookupInterface(id)
+			if !ok || iface.Name != tt.name {
+				t.Fatalf("LookupInterface(%s) = %+v, %v", tt.want, iface, ok)
+			}
+
+			if len(iface.Signatures) > 0 {
+				if got := hex.EncodeToString(InterfaceID(iface.Signatures...)); got != tt.want {
+					t.Errorf("InterfaceID() = %s, want %s", got, tt.want)
+				}
+			}
+		})
+	}
+}